@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CatalogHandler struct {
+	catalogUseCase *usecases.CatalogUseCase
+}
+
+// @name NewCatalogHandler - Creates new instance of catalog handler
+// @param catalogUseCase - bulk import/export service instance
+// @returns - new catalog handler instance
+func NewCatalogHandler(catalogUseCase *usecases.CatalogUseCase) *CatalogHandler {
+	return &CatalogHandler{catalogUseCase: catalogUseCase}
+}
+
+// @name ImportCatalog - Admin API to bulk create or update titles from a file
+// @param c - gin context
+// @query format - csv or jsonl, defaults to the uploaded file's extension
+// @query dry_run - true validates every row without writing content
+// @returns - queued import job, poll its status for per-row results
+// @dev - multipart form data with a "file" part (max 20MB), rows are upserted by external_id
+func (h *CatalogHandler) ImportCatalog(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	format := domain.CatalogFormat(c.Query("format"))
+	if format == "" {
+		if fileHeader, err := c.FormFile("file"); err == nil {
+			format = catalogFormatFromFilename(fileHeader.Filename)
+		}
+	}
+	if !format.IsValid() {
+		c.JSON(getErrorStatusCode(domain.ErrInvalidCatalogFormat), gin.H{"error": domain.ErrInvalidCatalogFormat.Error()})
+		return
+	}
+	data, ok := readUploadedFile(c, usecases.MaxCatalogImportBytes)
+	if !ok {
+		return
+	}
+	job, err := h.catalogUseCase.StartImport(c.Request.Context(), authorID, format, data, c.Query("dry_run") == "true")
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// @name GetImportJob - Admin API to check the progress of an import
+// @param c - gin context
+// @returns - import job with counts and row errors
+func (h *CatalogHandler) GetImportJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import job ID"})
+		return
+	}
+	job, err := h.catalogUseCase.GetImportJob(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// @name ExportCatalog - Admin API to download the full catalog
+// @param c - gin context
+// @query format - csv or jsonl, defaults to csv
+// @returns - file in the import layout, led by an id column
+func (h *CatalogHandler) ExportCatalog(c *gin.Context) {
+	format := domain.CatalogFormat(c.DefaultQuery("format", string(domain.CatalogFormatCSV)))
+	if !format.IsValid() {
+		c.JSON(getErrorStatusCode(domain.ErrInvalidCatalogFormat), gin.H{"error": domain.ErrInvalidCatalogFormat.Error()})
+		return
+	}
+	contentType := "text/csv"
+	if format == domain.CatalogFormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="catalog.`+string(format)+`"`)
+	c.Status(http.StatusOK)
+	if err := h.catalogUseCase.ExportCatalog(c.Request.Context(), format, c.Writer); err != nil {
+		// the body is already streaming, so the truncated file is all the client gets
+		log.Printf("Catalog export failed: %v", err)
+	}
+}
+
+// @name catalogFormatFromFilename - maps an upload's extension to a catalog format
+func catalogFormatFromFilename(name string) domain.CatalogFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return domain.CatalogFormatCSV
+	case ".jsonl", ".ndjson":
+		return domain.CatalogFormatJSONL
+	}
+	return ""
+}
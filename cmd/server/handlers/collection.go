@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CollectionHandler struct {
+	collectionUseCase   *usecases.CollectionUseCase
+	homeUseCase         *usecases.HomeUseCase
+	localizationUseCase *usecases.LocalizationUseCase
+}
+
+type HomeRailItemOutput struct {
+	Content  OpenContentOutput       `json:"content"`
+	Progress *usecases.WatchProgress `json:"progress,omitempty"`
+}
+
+type HomeRailOutput struct {
+	ID    string                `json:"id"`
+	Type  usecases.HomeRailType `json:"type"`
+	Title string                `json:"title"`
+	Items []HomeRailItemOutput  `json:"items"`
+}
+
+// @name NewCollectionHandler - Creates new instance of collection handler
+// @param collectionUseCase - collection service instance
+// @param homeUseCase - home screen service instance
+// @param localizationUseCase - translates titles and descriptions for the viewer
+// @returns - new collection handler instance
+func NewCollectionHandler(collectionUseCase *usecases.CollectionUseCase, homeUseCase *usecases.HomeUseCase, localizationUseCase *usecases.LocalizationUseCase) *CollectionHandler {
+	return &CollectionHandler{collectionUseCase: collectionUseCase, homeUseCase: homeUseCase, localizationUseCase: localizationUseCase}
+}
+
+// @name GetHome - Open API returning every home screen rail in one response
+// @param c - gin context
+// @returns - ordered rails of continue watching, collections, trending and genres
+// @dev - continue watching and plan gated collections need a signed in user
+func (h *CollectionHandler) GetHome(c *gin.Context) {
+	var userID *uuid.UUID
+	if id, err := uuid.Parse(c.GetString("userID")); err == nil {
+		userID = &id
+	}
+	rails, err := h.homeUseCase.GetHome(c.Request.Context(), userID, c.GetString("country"))
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	var contents []*domain.Content
+	for _, rail := range rails {
+		for _, item := range rail.Items {
+			contents = append(contents, item.Content)
+		}
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	outputs := make([]HomeRailOutput, len(rails))
+	for i, rail := range rails {
+		items := make([]HomeRailItemOutput, len(rail.Items))
+		for j, item := range rail.Items {
+			items[j] = HomeRailItemOutput{Content: toOpenContentOutput(item.Content), Progress: item.Progress}
+		}
+		outputs[i] = HomeRailOutput{ID: rail.ID, Type: rail.Type, Title: rail.Title, Items: items}
+	}
+	c.JSON(http.StatusOK, gin.H{"rails": outputs})
+}
+
+// @name CreateCollection - Admin API to create a curated collection
+// @param c - gin context
+// @returns - newly created collection with its items
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	var input usecases.CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	collection, err := h.collectionUseCase.CreateCollection(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, collection)
+}
+
+// @name ListCollections - Admin API to list all collections in rail order
+// @param c - gin context
+// @returns - list of collections including scheduled and expired ones
+func (h *CollectionHandler) ListCollections(c *gin.Context) {
+	collections, err := h.collectionUseCase.ListCollections(c.Request.Context())
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"collections": collections})
+}
+
+// @name GetCollection - Admin API to get a collection with its items
+// @param c - gin context
+// @returns - collection with ordered items
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection ID"})
+		return
+	}
+	collection, err := h.collectionUseCase.GetCollection(c.Request.Context(), collectionID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// @name UpdateCollection - Admin API to update a collection
+// @param c - gin context
+// @returns - updated collection
+// @dev - items are only replaced when content_ids is present in the body
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection ID"})
+		return
+	}
+	var input usecases.CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	collection, err := h.collectionUseCase.UpdateCollection(c.Request.Context(), collectionID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// @name SetCollectionItems - Admin API to replace the ordered items of a collection
+// @param c - gin context
+// @returns - updated collection
+func (h *CollectionHandler) SetCollectionItems(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection ID"})
+		return
+	}
+	var input usecases.SetCollectionItemsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.collectionUseCase.SetItems(c.Request.Context(), collectionID, input); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	collection, err := h.collectionUseCase.GetCollection(c.Request.Context(), collectionID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// @name DeleteCollection - Admin API to delete a collection
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection ID"})
+		return
+	}
+	if err := h.collectionUseCase.DeleteCollection(c.Request.Context(), collectionID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "collection deleted successfully"})
+}
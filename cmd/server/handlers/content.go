@@ -3,47 +3,96 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/cursor"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type ContentHandler struct {
-	contentUseCase *usecases.ContentUseCase
+	contentUseCase      *usecases.ContentUseCase
+	localizationUseCase *usecases.LocalizationUseCase
+	cursorCodec         *cursor.Codec
 }
 
 type OpenContentOutput struct {
-	ID           uuid.UUID           `json:"id"`
-	Title        string              `json:"title"`
-	Description  string              `json:"description"`
-	AccessLevel  *domain.AccessLevel `json:"access_level"`
-	Duration     int                 `json:"duration"`
-	ThumbnailURL string              `json:"thumbnail_url"`
-	TrailerURL   string              `json:"trailer_url"`
-	CreatedAt    time.Time           `json:"published_at"`
-	UpdatedAt    time.Time           `json:"updated_at"`
+	ID             uuid.UUID            `json:"id"`
+	Title          string               `json:"title"`
+	Description    string               `json:"description"`
+	Locale         string               `json:"locale"`
+	AccessLevel    *domain.AccessLevel  `json:"access_level"`
+	ContentType    domain.ContentType   `json:"content_type"`
+	Genre          string               `json:"genre,omitempty"`
+	Duration       int                  `json:"duration"`
+	ThumbnailURL   string               `json:"thumbnail_url"`
+	Thumbnails     domain.ImageVariants `json:"thumbnails,omitempty"`
+	BlurHash       string               `json:"thumbnail_blurhash,omitempty"`
+	DominantColor  string               `json:"thumbnail_color,omitempty"`
+	TrailerURL     string               `json:"trailer_url"`
+	RatingAverage  float64              `json:"rating_average"`
+	RatingCount    int                  `json:"rating_count"`
+	AvailableFrom  *time.Time           `json:"available_from,omitempty"`
+	AvailableUntil *time.Time           `json:"available_until,omitempty"`
+	CreatedAt      time.Time            `json:"published_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
 }
 
 // @name NewContentHandler - create a new instance of Content Handler
 // @param contentUseCase - content service instance
+// @param localizationUseCase - translates titles and descriptions for the viewer
+// @param cursorCodec - signs and verifies pagination cursors
 // @returns - content handler instance
-func NewContentHandler(contentUseCase *usecases.ContentUseCase) *ContentHandler {
-	return &ContentHandler{contentUseCase: contentUseCase}
+func NewContentHandler(contentUseCase *usecases.ContentUseCase, localizationUseCase *usecases.LocalizationUseCase, cursorCodec *cursor.Codec) *ContentHandler {
+	return &ContentHandler{contentUseCase: contentUseCase, localizationUseCase: localizationUseCase, cursorCodec: cursorCodec}
+}
+
+// @name toOpenContentOutput - maps content to its public representation
+// @param content - content entity
+// @returns - content output without video_url
+func toOpenContentOutput(content *domain.Content) OpenContentOutput {
+	return OpenContentOutput{
+		ID:             content.ID,
+		Title:          content.Title,
+		Description:    content.Description,
+		Locale:         content.Locale,
+		AccessLevel:    &content.AccessLevel,
+		ContentType:    content.ContentType,
+		Genre:          content.Genre,
+		Duration:       content.DurationSeconds,
+		ThumbnailURL:   content.ThumbnailURL,
+		Thumbnails:     content.ThumbnailVariants,
+		BlurHash:       content.ThumbnailBlurHash,
+		DominantColor:  content.ThumbnailColor,
+		TrailerURL:     content.TrailerURL,
+		RatingAverage:  content.RatingAverage,
+		RatingCount:    content.RatingCount,
+		AvailableFrom:  content.AvailableFrom,
+		AvailableUntil: content.AvailableUntil,
+		CreatedAt:      content.CreatedAt,
+		UpdatedAt:      content.UpdatedAt,
+	}
 }
 
 // @name CreateContent - admin creates new content with access level
 // @param c - gin context
 // @returns - newly created content
 func (h *ContentHandler) CreateContent(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
 	var input usecases.CreateContentInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	content, err := h.contentUseCase.CreateContent(c.Request.Context(), input)
+	content, err := h.contentUseCase.CreateContent(c.Request.Context(), authorID, input)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -68,65 +117,146 @@ func (h *ContentHandler) GetContent(c *gin.Context) {
 			userID = &id
 		}
 	}
-	content, err := h.contentUseCase.GetContent(c.Request.Context(), contentID, userID)
+	content, err := h.contentUseCase.GetContent(c.Request.Context(), contentID, userID, c.GetString("country"))
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, OpenContentOutput{
-		ID:           content.ID,
-		Title:        content.Title,
-		Description:  content.Description,
-		AccessLevel:  &content.AccessLevel,
-		Duration:     content.DurationSeconds,
-		ThumbnailURL: content.ThumbnailURL,
-		TrailerURL:   content.TrailerURL,
-		CreatedAt:    content.CreatedAt,
-		UpdatedAt:    content.UpdatedAt,
-	})
+	if err := localizeContents(c, h.localizationUseCase, content); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Language", content.Locale)
+	c.JSON(http.StatusOK, toOpenContentOutput(content))
 }
 
 // @name ListContent - Open API to get all content w/ pagination
 // @param c - gin context
-// @returns - list of content
+// @query person - optional cast/crew name to search titles by
+// @query q - optional text matched against title and description
+// @query access_level - comma separated access levels, e.g. free,basic
+// @query genre - optional genre
+// @query min_duration, max_duration - inclusive duration bounds in seconds
+// @query created_after, created_before - RFC 3339 publish date range
+// @query published - defaults to true, false also lists unpublished titles
+// @query sort - title, created_at or duration, "-" prefix for descending, defaults to -created_at
+// @query cursor - next_cursor of the previous page
+// @query limit - page size, defaults to 20 and is capped at 100
+// @returns - list of content with next_cursor, null on the last page
 // @dev - removes video_url so anyone can see content
 func (h *ContentHandler) ListContent(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	publishedOnly := c.DefaultQuery("published", "true") == "true"
-	contents, total, err := h.contentUseCase.ListContent(c.Request.Context(), publishedOnly, limit, offset)
+	page, err := parsePage(c, h.cursorCodec, "content", repositories.SortCreatedAt, true)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
+	filter, err := parseContentFilter(c)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	var contents []*domain.Content
+	var total int64
+	var next *repositories.Keyset
+	if person := c.Query("person"); person != "" {
+		contents, total, next, err = h.contentUseCase.ListContentByPerson(c.Request.Context(), person, filter, c.GetString("country"), page)
+	} else {
+		contents, total, next, err = h.contentUseCase.ListContent(c.Request.Context(), filter, c.GetString("country"), page)
+	}
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
 
 	outputs := make([]OpenContentOutput, len(contents))
-	for i, c := range contents {
-		outputs[i] = OpenContentOutput{
-			ID:           c.ID,
-			Title:        c.Title,
-			Description:  c.Description,
-			AccessLevel:  &c.AccessLevel,
-			Duration:     c.DurationSeconds,
-			ThumbnailURL: c.ThumbnailURL,
-			TrailerURL:   c.TrailerURL,
-			CreatedAt:    c.CreatedAt,
-			UpdatedAt:    c.UpdatedAt,
-		}
+	for i, content := range contents {
+		outputs[i] = toOpenContentOutput(content)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"contents": outputs,
-		"total":    total,
-		"limit":    limit,
-		"offset":   offset,
+		"contents":    outputs,
+		"total":       total,
+		"next_cursor": nextCursor(h.cursorCodec, "content", page, next),
 	})
 }
 
+// @name parseContentFilter - reads the catalog filter query parameters
+// @param c - gin context
+// @returns - validated content filter or ErrInvalidFilter
+func parseContentFilter(c *gin.Context) (repositories.ContentFilter, error) {
+	var filter repositories.ContentFilter
+	if c.DefaultQuery("published", "true") == "true" {
+		published := true
+		filter.Published = &published
+	}
+	if levels := c.Query("access_level"); levels != "" {
+		for _, level := range strings.Split(levels, ",") {
+			filter.AccessLevels = append(filter.AccessLevels, domain.AccessLevel(strings.ToLower(strings.TrimSpace(level))))
+		}
+	}
+	for param, target := range map[string]**int{"min_duration": &filter.MinDuration, "max_duration": &filter.MaxDuration} {
+		if raw := c.Query(param); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return filter, domain.ErrInvalidFilter
+			}
+			*target = &value
+		}
+	}
+	for param, target := range map[string]**time.Time{"created_after": &filter.CreatedAfter, "created_before": &filter.CreatedBefore} {
+		if raw := c.Query(param); raw != "" {
+			value, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return filter, domain.ErrInvalidFilter
+			}
+			*target = &value
+		}
+	}
+	filter.Genre = domain.NormalizeGenre(c.Query("genre"))
+	filter.Query = strings.TrimSpace(c.Query("q"))
+	return filter, filter.Validate()
+}
+
+// @name ListLeavingSoon - Open API to list titles whose availability ends soon
+// @param c - gin context
+// @query days - lookahead window in days, defaults to 7
+// @returns - list of content ordered by availability end
+func (h *ContentHandler) ListLeavingSoon(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+		return
+	}
+	contents, err := h.contentUseCase.ListLeavingSoon(c.Request.Context(), c.GetString("country"), time.Duration(days)*24*time.Hour)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	outputs := make([]OpenContentOutput, len(contents))
+	for i, content := range contents {
+		outputs[i] = toOpenContentOutput(content)
+	}
+	c.JSON(http.StatusOK, gin.H{"contents": outputs})
+}
+
 // @name UpdateContent - Admin API to update existing content
 // @param c - gin context
 // @returns - updated content
+// @dev - every change is recorded as a revision authored by the admin
 func (h *ContentHandler) UpdateContent(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
 	contentID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
@@ -137,7 +267,146 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	content, err := h.contentUseCase.UpdateContent(c.Request.Context(), contentID, input)
+	content, err := h.contentUseCase.UpdateContent(c.Request.Context(), authorID, contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, content)
+}
+
+// @name SaveDraft - Admin API to save a draft revision without changing the live content
+// @param c - gin context
+// @returns - draft revision with its changes against the live content
+func (h *ContentHandler) SaveDraft(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.CreateContentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	draft, err := h.contentUseCase.SaveDraft(c.Request.Context(), authorID, contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, draft)
+}
+
+// @name DiscardDraft - Admin API to throw away the content's draft
+// @param c - gin context
+// @returns - confirmation message
+func (h *ContentHandler) DiscardDraft(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	if err := h.contentUseCase.DiscardDraft(c.Request.Context(), contentID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "draft discarded"})
+}
+
+// @name ListRevisions - Admin API to list the edit history of content
+// @param c - gin context
+// @returns - revisions with author and changed fields, newest first
+func (h *ContentHandler) ListRevisions(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	revisions, err := h.contentUseCase.ListRevisions(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// @name RestoreRevision - Admin API to make an earlier revision live again
+// @param c - gin context
+// @returns - updated content
+// @dev - restoring the draft revision publishes it
+func (h *ContentHandler) RestoreRevision(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision number"})
+		return
+	}
+	content, err := h.contentUseCase.RestoreRevision(c.Request.Context(), authorID, contentID, number)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, content)
+}
+
+// @name UpdateTerritories - Admin API to bulk replace licensed territories
+// @param c - gin context
+// @returns - number of content rows updated
+// @dev - empty allowed_countries means worldwide except blocked_countries, each changed content gets a revision
+func (h *ContentHandler) UpdateTerritories(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	var input usecases.UpdateTerritoriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := h.contentUseCase.UpdateTerritories(c.Request.Context(), authorID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// @name UpdateMarkers - Admin API to replace skip intro, skip credits and chapter markers
+// @param c - gin context
+// @returns - content with its new markers
+// @dev - the credits marker becomes the point where viewing counts as completed, changes are recorded as a revision
+func (h *ContentHandler) UpdateMarkers(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var markers domain.ContentMarkers
+	if err := c.ShouldBindJSON(&markers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	content, err := h.contentUseCase.UpdateMarkers(c.Request.Context(), authorID, contentID, markers)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -148,6 +417,7 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 // @name GetContent - Admin API to delete content with ID
 // @param c - gin context
 // @returns - deletion confirmation message
+// @dev - content is moved to the trash and purged after the retention period
 func (h *ContentHandler) DeleteContent(c *gin.Context) {
 	contentID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -158,5 +428,34 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "content deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "content moved to trash"})
+}
+
+// @name ListTrash - Admin API to list deleted content awaiting purge
+// @param c - gin context
+// @returns - deleted content, most recently deleted first
+func (h *ContentHandler) ListTrash(c *gin.Context) {
+	contents, err := h.contentUseCase.ListDeletedContent(c.Request.Context())
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"contents": contents})
+}
+
+// @name RestoreContent - Admin API to bring deleted content back from the trash
+// @param c - gin context
+// @returns - restored content
+func (h *ContentHandler) RestoreContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	content, err := h.contentUseCase.RestoreContent(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, content)
 }
@@ -18,18 +18,37 @@ func getErrorStatusCode(err error) int {
 	case domain.ErrUserExists:
 		return http.StatusConflict
 	case domain.ErrUserNotFound, domain.ErrContentNotFound, domain.ErrPlanNotFound,
-		domain.ErrSubscriptionNotFound, domain.ErrWatchHistoryNotFound, domain.ErrNotFound:
+		domain.ErrSubscriptionNotFound, domain.ErrWatchHistoryNotFound, domain.ErrPersonNotFound,
+		domain.ErrCreditNotFound, domain.ErrPlaybackUnavailable, domain.ErrRenditionNotFound, domain.ErrTrackNotFound, domain.ErrReviewNotFound, domain.ErrWatchlistItemNotFound,
+		domain.ErrCollectionNotFound, domain.ErrRevisionNotFound, domain.ErrDraftNotFound, domain.ErrImportJobNotFound, domain.ErrTranslationNotFound,
+		domain.ErrNotFound:
 		return http.StatusNotFound
-	case domain.ErrContentNotAccessible, domain.ErrContentNotPublished:
+	case domain.ErrContentNotAccessible, domain.ErrContentNotPublished, domain.ErrContentNotAvailable,
+		domain.ErrRatingRequiresWatch:
 		return http.StatusForbidden
+	case domain.ErrContentNotAvailableInRegion:
+		return http.StatusUnavailableForLegalReasons
 	case domain.ErrPlanNotAvailable, domain.ErrInactivePlan:
 		return http.StatusBadRequest
-	case domain.ErrActiveSubscriptionExists, domain.ErrSubscriptionLimitExceeded:
+	case domain.ErrActiveSubscriptionExists, domain.ErrSubscriptionLimitExceeded, domain.ErrCreditExists,
+		domain.ErrRenditionExists, domain.ErrTrackExists, domain.ErrDefaultTrackExists,
+		domain.ErrWatchlistItemExists:
 		return http.StatusConflict
 	case domain.ErrSubscriptionExpired, domain.ErrSubscriptionInactive:
 		return http.StatusBadRequest
-	case domain.ErrInvalidInput, domain.ErrValidationFailed, domain.ErrInvalidProgress:
+	case domain.ErrInvalidInput, domain.ErrValidationFailed, domain.ErrInvalidProgress, domain.ErrInvalidAvailability,
+		domain.ErrInvalidCountryCode, domain.ErrInvalidResolution, domain.ErrInvalidManifestFormat,
+		domain.ErrInvalidLanguageTag, domain.ErrInvalidSubtitle, domain.ErrSubtitleSource,
+		domain.ErrInvalidReviewStatus, domain.ErrInvalidWatchlistOrder,
+		domain.ErrInvalidTrendingWindow, domain.ErrInvalidVisibility,
+		domain.ErrInvalidCursor, domain.ErrInvalidSort, domain.ErrInvalidFilter,
+		domain.ErrInvalidCatalogFormat, domain.ErrInvalidImportFile, domain.ErrDefaultLocaleTranslation, domain.ErrInvalidMarkers,
+		domain.ErrProgressBeyondDuration, domain.ErrClientTimeInFuture, domain.ErrInvalidStatsPeriod, domain.ErrInvalidReviewYear:
 		return http.StatusBadRequest
+	case domain.ErrFileTooLarge, domain.ErrImageTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case domain.ErrUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
 	default:
 		return http.StatusInternalServerError
 	}
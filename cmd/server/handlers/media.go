@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MediaHandler struct {
+	mediaUseCase *usecases.MediaUseCase
+}
+
+// @name NewMediaHandler - Creates new instance of media handler
+// @param mediaUseCase - media service instance
+// @returns - new media handler instance
+func NewMediaHandler(mediaUseCase *usecases.MediaUseCase) *MediaHandler {
+	return &MediaHandler{mediaUseCase: mediaUseCase}
+}
+
+// @name UploadArtwork - Admin API to upload poster artwork for content
+// @param c - gin context
+// @returns - content with thumbnail_url pointing at the stored image
+// @dev - multipart form data with a "file" part (JPEG, PNG, WebP or GIF, max 10MB), a new thumbnail is recorded as a revision
+func (h *MediaHandler) UploadArtwork(c *gin.Context) {
+	authorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	data, ok := readUploadedFile(c, usecases.MaxArtworkBytes)
+	if !ok {
+		return
+	}
+	content, err := h.mediaUseCase.UploadArtwork(c.Request.Context(), authorID, contentID, data)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, content)
+}
+
+// @name UploadAvatar - Uploads the user's profile picture
+// @param c - gin context
+// @returns - updated profile
+// @dev - multipart form data with a "file" part (JPEG, PNG, WebP or GIF, max 2MB)
+func (h *MediaHandler) UploadAvatar(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	data, ok := readUploadedFile(c, usecases.MaxAvatarBytes)
+	if !ok {
+		return
+	}
+	user, err := h.mediaUseCase.UploadAvatar(c.Request.Context(), userID, data)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// @name readUploadedFile - reads the multipart "file" part, writing the error response on failure
+func readUploadedFile(c *gin.Context, maxBytes int64) ([]byte, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return nil, false
+	}
+	if fileHeader.Size > maxBytes {
+		c.JSON(getErrorStatusCode(domain.ErrFileTooLarge), gin.H{"error": domain.ErrFileTooLarge.Error()})
+		return nil, false
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/cursor"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// parsePage reads the limit, sort and cursor query parameters of a keyset
+// paginated listing. sort is a field name, prefixed with "-" for descending
+// order. A cursor carries the sort it was issued with, so a follow-up request
+// may leave sort out but cannot switch to a different one.
+func parsePage(c *gin.Context, codec *cursor.Codec, kind string, defaultSort repositories.SortKey, defaultDesc bool) (repositories.Page, error) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	page := repositories.Page{Sort: defaultSort, Desc: defaultDesc, Limit: limit}
+	sort, sortSet := c.GetQuery("sort")
+	if sortSet {
+		page.Desc = strings.HasPrefix(sort, "-")
+		page.Sort = repositories.SortKey(strings.TrimPrefix(sort, "-"))
+	}
+	token := c.Query("cursor")
+	if token == "" {
+		return page, nil
+	}
+	position, err := codec.Decode(token)
+	if err != nil || position.Kind != kind {
+		return page, domain.ErrInvalidCursor
+	}
+	if sortSet && (repositories.SortKey(position.Sort) != page.Sort || position.Desc != page.Desc) {
+		return page, domain.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(position.ID)
+	if err != nil {
+		return page, domain.ErrInvalidCursor
+	}
+	page.Sort = repositories.SortKey(position.Sort)
+	page.Desc = position.Desc
+	page.After = &repositories.Keyset{Value: position.Value, ID: id}
+	return page, nil
+}
+
+// nextCursor signs the position after the returned page, nil marks the last page
+func nextCursor(codec *cursor.Codec, kind string, page repositories.Page, next *repositories.Keyset) *string {
+	if next == nil {
+		return nil
+	}
+	token := codec.Encode(cursor.Position{
+		Kind:  kind,
+		Sort:  string(page.Sort),
+		Desc:  page.Desc,
+		Value: next.Value,
+		ID:    next.ID.String(),
+	})
+	return &token
+}
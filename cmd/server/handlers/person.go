@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PersonHandler struct {
+	personUseCase       *usecases.PersonUseCase
+	localizationUseCase *usecases.LocalizationUseCase
+}
+
+type FilmographyOutput struct {
+	Role          domain.PersonRole `json:"role"`
+	CharacterName string            `json:"character_name,omitempty"`
+	Content       OpenContentOutput `json:"content"`
+}
+
+// @name NewPersonHandler - Creates new instance of person handler
+// @param personUseCase - person service instance
+// @param localizationUseCase - translates filmography titles for the viewer
+// @returns - new person handler instance
+func NewPersonHandler(personUseCase *usecases.PersonUseCase, localizationUseCase *usecases.LocalizationUseCase) *PersonHandler {
+	return &PersonHandler{personUseCase: personUseCase, localizationUseCase: localizationUseCase}
+}
+
+// @name CreatePerson - Admin API to add a cast/crew member
+// @param c - gin context
+// @returns - newly created person
+func (h *PersonHandler) CreatePerson(c *gin.Context) {
+	var input usecases.CreatePersonInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	person, err := h.personUseCase.CreatePerson(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, person)
+}
+
+// @name GetPerson - Open API to get a person with their filmography
+// @param c - gin context
+// @returns - person with published titles the caller can access
+func (h *PersonHandler) GetPerson(c *gin.Context) {
+	personID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid person ID"})
+		return
+	}
+	var userID *uuid.UUID
+	if userIDStr := c.GetString("userID"); userIDStr != "" {
+		id, err := uuid.Parse(userIDStr)
+		if err == nil {
+			userID = &id
+		}
+	}
+	person, credits, err := h.personUseCase.GetPerson(c.Request.Context(), personID, userID, c.GetString("country"))
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	contents := make([]*domain.Content, len(credits))
+	for i, credit := range credits {
+		contents[i] = credit.Content
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	filmography := make([]FilmographyOutput, len(credits))
+	for i, credit := range credits {
+		filmography[i] = FilmographyOutput{
+			Role:          credit.Role,
+			CharacterName: credit.CharacterName,
+			Content:       toOpenContentOutput(credit.Content),
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"person": person, "filmography": filmography})
+}
+
+// @name ListPeople - Open API to search cast and crew
+// @param c - gin context
+// @query q - optional name to search for
+// @returns - list of people
+func (h *PersonHandler) ListPeople(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	people, total, err := h.personUseCase.ListPeople(c.Request.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"people": people,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// @name UpdatePerson - Admin API to update a person
+// @param c - gin context
+// @returns - updated person
+func (h *PersonHandler) UpdatePerson(c *gin.Context) {
+	personID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid person ID"})
+		return
+	}
+	var input usecases.CreatePersonInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	person, err := h.personUseCase.UpdatePerson(c.Request.Context(), personID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, person)
+}
+
+// @name DeletePerson - Admin API to delete a person and their credits
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *PersonHandler) DeletePerson(c *gin.Context) {
+	personID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid person ID"})
+		return
+	}
+	if err := h.personUseCase.DeletePerson(c.Request.Context(), personID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "person deleted successfully"})
+}
+
+// @name GetContentCredits - Open API to get cast and crew of a content
+// @param c - gin context
+// @returns - credits ordered by billing
+func (h *PersonHandler) GetContentCredits(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	credits, err := h.personUseCase.GetContentCredits(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"credits": credits})
+}
+
+// @name AddCredit - Admin API to credit a person on a content
+// @param c - gin context
+// @returns - newly created credit
+func (h *PersonHandler) AddCredit(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.AddCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	credit, err := h.personUseCase.AddCredit(c.Request.Context(), contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, credit)
+}
+
+// @name RemoveCredit - Admin API to remove a credit from a content
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *PersonHandler) RemoveCredit(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	creditID, err := uuid.Parse(c.Param("creditId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid credit ID"})
+		return
+	}
+	if err := h.personUseCase.RemoveCredit(c.Request.Context(), contentID, creditID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "credit removed successfully"})
+}
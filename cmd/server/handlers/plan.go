@@ -9,14 +9,16 @@ import (
 )
 
 type PlanHandler struct {
-	planUseCase *usecases.PlanUseCase
+	planUseCase         *usecases.PlanUseCase
+	localizationUseCase *usecases.LocalizationUseCase
 }
 
 // @name NewPlanHandler - Creates new instance of plan handler
 // @param planUseCase - plan usecase (service)
+// @param localizationUseCase - translates plan names and descriptions for the viewer
 // @returns - new instance of plan handler
-func NewPlanHandler(planUseCase *usecases.PlanUseCase) *PlanHandler {
-	return &PlanHandler{planUseCase: planUseCase}
+func NewPlanHandler(planUseCase *usecases.PlanUseCase, localizationUseCase *usecases.LocalizationUseCase) *PlanHandler {
+	return &PlanHandler{planUseCase: planUseCase, localizationUseCase: localizationUseCase}
 }
 
 // @name CreatePlan - Admin API creates new plan
@@ -50,6 +52,11 @@ func (h *PlanHandler) GetPlan(c *gin.Context) {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
+	if err := localizePlans(c, h.localizationUseCase, plan); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Language", plan.Locale)
 	c.JSON(http.StatusOK, plan)
 }
 
@@ -64,6 +71,10 @@ func (h *PlanHandler) ListPlans(c *gin.Context) {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
+	if err := localizePlans(c, h.localizationUseCase, plans...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"plans": plans})
 }
 
@@ -92,6 +103,7 @@ func (h *PlanHandler) UpdatePlan(c *gin.Context) {
 // @name GetContent - Admin API to delete plan with given ID
 // @param c - gin context
 // @returns - deletion successful message
+// @dev - subscribers keep the plan, it is purged once none reference it
 func (h *PlanHandler) DeletePlan(c *gin.Context) {
 	planID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -102,5 +114,34 @@ func (h *PlanHandler) DeletePlan(c *gin.Context) {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "plan deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "plan moved to trash"})
+}
+
+// @name ListTrash - Admin API to list deleted plans awaiting purge
+// @param c - gin context
+// @returns - deleted plans, most recently deleted first
+func (h *PlanHandler) ListTrash(c *gin.Context) {
+	plans, err := h.planUseCase.ListDeletedPlans(c.Request.Context())
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"plans": plans})
+}
+
+// @name RestorePlan - Admin API to bring a deleted plan back from the trash
+// @param c - gin context
+// @returns - restored plan
+func (h *PlanHandler) RestorePlan(c *gin.Context) {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid plan ID"})
+		return
+	}
+	plan, err := h.planUseCase.RestorePlan(c.Request.Context(), planID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
 }
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PlaybackHandler struct {
+	playbackUseCase *usecases.PlaybackUseCase
+}
+
+// @name NewPlaybackHandler - Creates new instance of playback handler
+// @param playbackUseCase - playback service instance
+// @returns - new playback handler instance
+func NewPlaybackHandler(playbackUseCase *usecases.PlaybackUseCase) *PlaybackHandler {
+	return &PlaybackHandler{playbackUseCase: playbackUseCase}
+}
+
+// @name CreatePlaybackSession - Protected API to get a signed video URL for content
+// @param c - gin context
+// @returns - signed, expiring video URL bound to the user (and optionally IP)
+// @dev - body is optional, send {"bind_ip": true} to lock the URL to the caller's IP
+func (h *PlaybackHandler) CreatePlaybackSession(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.PlaybackInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	session, err := h.playbackUseCase.CreatePlaybackSession(c.Request.Context(), contentID, userID, c.GetString("country"), c.ClientIP(), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, session)
+}
+
+// @name GetHLSManifest - Protected API to get an HLS master playlist for content
+// @param c - gin context
+// @returns - .m3u8 master playlist listing signed variants allowed by the user's plan
+// @query - bind_ip (optional, "true" locks variant URIs to the caller's IP)
+func (h *PlaybackHandler) GetHLSManifest(c *gin.Context) {
+	h.writeManifest(c, usecases.ManifestFormatHLS)
+}
+
+// @name GetDASHManifest - Protected API to get a DASH MPD for content
+// @param c - gin context
+// @returns - MPD listing signed representations allowed by the user's plan
+// @query - bind_ip (optional, "true" locks representation URLs to the caller's IP)
+func (h *PlaybackHandler) GetDASHManifest(c *gin.Context) {
+	h.writeManifest(c, usecases.ManifestFormatDASH)
+}
+
+func (h *PlaybackHandler) writeManifest(c *gin.Context, format usecases.ManifestFormat) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	bindIP, _ := strconv.ParseBool(c.DefaultQuery("bind_ip", "false"))
+	manifest, err := h.playbackUseCase.CreateManifest(c.Request.Context(), contentID, userID, c.GetString("country"), c.ClientIP(), format, usecases.PlaybackInput{BindIP: bindIP})
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	// Variant URIs are signed per user, so shared caches must not keep them
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, manifest.ContentType, manifest.Body)
+}
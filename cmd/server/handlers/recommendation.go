@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RecommendationHandler struct {
+	recommendationUseCase *usecases.RecommendationUseCase
+}
+
+// @name NewRecommendationHandler - Creates new instance of recommendation handler
+// @param recommendationUseCase - recommendation service instance
+// @returns - new recommendation handler instance
+func NewRecommendationHandler(recommendationUseCase *usecases.RecommendationUseCase) *RecommendationHandler {
+	return &RecommendationHandler{recommendationUseCase: recommendationUseCase}
+}
+
+// @name GetRecommendations - Protected API to get personalized content recommendations
+// @param c - gin context
+// @returns - recommended content with score and reason
+// @query - limit (default 20, max 50)
+// @dev - only content the user's plan can play is returned, users without
+// enough history get popular content with reason "popular"
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	recommendations, err := h.recommendationUseCase.GetRecommendations(c.Request.Context(), userID, c.GetString("country"), limit)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": recommendations, "total": len(recommendations)})
+}
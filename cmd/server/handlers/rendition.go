@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RenditionHandler struct {
+	renditionUseCase *usecases.RenditionUseCase
+}
+
+// @name NewRenditionHandler - Creates new instance of rendition handler
+// @param renditionUseCase - rendition service instance
+// @returns - new rendition handler instance
+func NewRenditionHandler(renditionUseCase *usecases.RenditionUseCase) *RenditionHandler {
+	return &RenditionHandler{renditionUseCase: renditionUseCase}
+}
+
+// @name AddRendition - Admin API to register a video rendition for content
+// @param c - gin context
+// @returns - newly created rendition
+func (h *RenditionHandler) AddRendition(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.CreateRenditionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rendition, err := h.renditionUseCase.AddRendition(c.Request.Context(), contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, rendition)
+}
+
+// @name ListRenditions - Admin API to list all renditions of content
+// @param c - gin context
+// @returns - renditions ordered by bitrate
+func (h *RenditionHandler) ListRenditions(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	renditions, err := h.renditionUseCase.ListRenditions(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"renditions": renditions})
+}
+
+// @name RemoveRendition - Admin API to delete a rendition from content
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *RenditionHandler) RemoveRendition(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	renditionID, err := uuid.Parse(c.Param("renditionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rendition ID"})
+		return
+	}
+	if err := h.renditionUseCase.RemoveRendition(c.Request.Context(), contentID, renditionID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "rendition deleted successfully"})
+}
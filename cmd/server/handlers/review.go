@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	reviewUseCase *usecases.ReviewUseCase
+}
+
+type ReviewOutput struct {
+	ID         uuid.UUID `json:"id"`
+	Rating     int       `json:"rating"`
+	Body       string    `json:"body"`
+	AuthorName string    `json:"author_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// @name NewReviewHandler - Creates new instance of review handler
+// @param reviewUseCase - review service instance
+// @returns - new review handler instance
+func NewReviewHandler(reviewUseCase *usecases.ReviewUseCase) *ReviewHandler {
+	return &ReviewHandler{reviewUseCase: reviewUseCase}
+}
+
+// @name RateContent - Protected API to rate and optionally review watched content
+// @param c - gin context
+// @returns - the user's review, text reviews start pending moderation
+func (h *ReviewHandler) RateContent(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.ReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := h.reviewUseCase.RateContent(c.Request.Context(), userID, contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// @name GetMyReview - Protected API to get the user's own review of content
+// @param c - gin context
+// @returns - the user's review including moderation status
+func (h *ReviewHandler) GetMyReview(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	review, err := h.reviewUseCase.GetMyReview(c.Request.Context(), userID, contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// @name DeleteMyReview - Protected API to withdraw the user's rating and review
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *ReviewHandler) DeleteMyReview(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	if err := h.reviewUseCase.DeleteMyReview(c.Request.Context(), userID, contentID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
+}
+
+// @name ListContentReviews - Open API to list approved text reviews of content
+// @param c - gin context
+// @returns - paginated reviews with author display names
+func (h *ReviewHandler) ListContentReviews(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	reviews, total, err := h.reviewUseCase.ListContentReviews(c.Request.Context(), contentID, limit, offset)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]ReviewOutput, 0, len(reviews))
+	for _, review := range reviews {
+		item := ReviewOutput{ID: review.ID, Rating: review.Rating, Body: review.Body, CreatedAt: review.CreatedAt}
+		if review.User != nil {
+			item.AuthorName = review.User.Name
+		}
+		output = append(output, item)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": output,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// @name ListModerationQueue - Admin API to list reviews by moderation status
+// @param c - gin context
+// @returns - paginated reviews, oldest first
+// @query - status (pending by default, approved or rejected)
+func (h *ReviewHandler) ListModerationQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	status := domain.ReviewStatus(c.Query("status"))
+	reviews, total, err := h.reviewUseCase.ListModerationQueue(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// @name ModerateReview - Admin API to approve or reject a review
+// @param c - gin context
+// @returns - moderated review
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	moderatorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review ID"})
+		return
+	}
+	var input usecases.ModerateReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := h.reviewUseCase.ModerateReview(c.Request.Context(), moderatorID, reviewID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// @name DeleteReview - Admin API to remove a review and its rating
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review ID"})
+		return
+	}
+	if err := h.reviewUseCase.DeleteReview(c.Request.Context(), reviewID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
+}
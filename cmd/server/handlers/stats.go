@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StatsHandler struct {
+	statsUseCase        *usecases.StatsUseCase
+	localizationUseCase *usecases.LocalizationUseCase
+}
+
+type TitleActivityOutput struct {
+	Content      OpenContentOutput `json:"content"`
+	WatchSeconds int64             `json:"watch_seconds"`
+	RewatchCount int               `json:"rewatch_count"`
+	Completed    bool              `json:"completed"`
+}
+
+type YearInReviewOutput struct {
+	Year int `json:"year"`
+	domain.WatchTotals
+	FavoriteGenres []*domain.GenreActivity `json:"favorite_genres"`
+	TopTitles      []TitleActivityOutput   `json:"top_titles"`
+	Months         []*domain.MonthActivity `json:"months"`
+	Streaks        domain.Streaks          `json:"streaks"`
+}
+
+// @name NewStatsHandler - Creates new instance of stats handler
+// @param statsUseCase - viewer statistics service instance
+// @param localizationUseCase - translates titles and descriptions for the viewer
+// @returns - new stats handler instance
+func NewStatsHandler(statsUseCase *usecases.StatsUseCase, localizationUseCase *usecases.LocalizationUseCase) *StatsHandler {
+	return &StatsHandler{statsUseCase: statsUseCase, localizationUseCase: localizationUseCase}
+}
+
+// @name GetStats - Gets the user's viewing statistics
+// @param c - gin context
+// @query period - week, month, year or all, defaults to month
+// @returns - watch time, titles watched and completed, favorite genres and streaks
+// @dev - periods are rolling and streaks count UTC days
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	period := domain.StatsPeriod(c.DefaultQuery("period", string(domain.StatsPeriodMonth)))
+	stats, err := h.statsUseCase.GetStats(c.Request.Context(), userID, period)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// @name GetYearInReview - Gets the user's recap of a calendar year
+// @param c - gin context
+// @query year - year to recap, defaults to the current year
+// @returns - year totals, favorite genres, most watched titles, monthly breakdown and streaks
+func (h *StatsHandler) GetYearInReview(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().UTC().Year())))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrInvalidReviewYear.Error()})
+		return
+	}
+	review, err := h.statsUseCase.GetYearInReview(c.Request.Context(), userID, year)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	contents := make([]*domain.Content, 0, len(review.TopTitles))
+	for _, title := range review.TopTitles {
+		if title.Content != nil {
+			contents = append(contents, title.Content)
+		}
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	output := YearInReviewOutput{
+		Year:           review.Year,
+		WatchTotals:    review.WatchTotals,
+		FavoriteGenres: review.FavoriteGenres,
+		TopTitles:      make([]TitleActivityOutput, 0, len(review.TopTitles)),
+		Months:         review.Months,
+		Streaks:        review.Streaks,
+	}
+	for _, title := range review.TopTitles {
+		if title.Content == nil {
+			continue
+		}
+		output.TopTitles = append(output.TopTitles, TitleActivityOutput{
+			Content:      toOpenContentOutput(title.Content),
+			WatchSeconds: title.WatchSeconds,
+			RewatchCount: title.RewatchCount,
+			Completed:    title.Completed,
+		})
+	}
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxSubtitleFileBytes = 2 << 20
+
+type TrackHandler struct {
+	trackUseCase *usecases.TrackUseCase
+}
+
+// @name NewTrackHandler - Creates new instance of track handler
+// @param trackUseCase - track service instance
+// @returns - new track handler instance
+func NewTrackHandler(trackUseCase *usecases.TrackUseCase) *TrackHandler {
+	return &TrackHandler{trackUseCase: trackUseCase}
+}
+
+// @name AddSubtitle - Admin API to register or upload a subtitle track for content
+// @param c - gin context
+// @returns - newly created subtitle track
+// @dev - send JSON with a url to register a hosted file, or multipart form data with a
+// "file" part (SRT or WebVTT) to upload, SRT uploads are converted to WebVTT
+func (h *TrackHandler) AddSubtitle(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.AddSubtitleInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		var ok bool
+		if input.File, ok = readUploadedFile(c, maxSubtitleFileBytes); !ok {
+			return
+		}
+	}
+	track, err := h.trackUseCase.AddSubtitle(c.Request.Context(), contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, track)
+}
+
+// @name AddAudioTrack - Admin API to register an alternate audio track for content
+// @param c - gin context
+// @returns - newly created audio track
+func (h *TrackHandler) AddAudioTrack(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.AddAudioTrackInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	track, err := h.trackUseCase.AddAudioTrack(c.Request.Context(), contentID, input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, track)
+}
+
+// @name ListTracks - Admin API to list subtitle and audio tracks of content
+// @param c - gin context
+// @returns - subtitle and audio tracks
+func (h *TrackHandler) ListTracks(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	tracks, err := h.trackUseCase.ListTracks(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tracks)
+}
+
+// @name RemoveSubtitle - Admin API to delete a subtitle track from content
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *TrackHandler) RemoveSubtitle(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	trackID, err := uuid.Parse(c.Param("trackId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid track ID"})
+		return
+	}
+	if err := h.trackUseCase.RemoveSubtitle(c.Request.Context(), contentID, trackID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "subtitle track deleted successfully"})
+}
+
+// @name RemoveAudioTrack - Admin API to delete an audio track from content
+// @param c - gin context
+// @returns - deletion confirmation message
+func (h *TrackHandler) RemoveAudioTrack(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	trackID, err := uuid.Parse(c.Param("trackId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid track ID"})
+		return
+	}
+	if err := h.trackUseCase.RemoveAudioTrack(c.Request.Context(), contentID, trackID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "audio track deleted successfully"})
+}
+
+// @name GetSubtitleFile - Public API serving an uploaded WebVTT subtitle file
+// @param c - gin context
+// @returns - text/vtt body
+// @dev - only reachable through the signed URL returned in a playback session
+func (h *TrackHandler) GetSubtitleFile(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	trackID, err := uuid.Parse(strings.TrimSuffix(c.Param("trackFile"), ".vtt"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid track ID"})
+		return
+	}
+	body, err := h.trackUseCase.GetSubtitleFile(c.Request.Context(), contentID, trackID, c.Request.URL.RequestURI(), c.ClientIP())
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, "text/vtt; charset=utf-8", body)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TranslationHandler struct {
+	localizationUseCase *usecases.LocalizationUseCase
+}
+
+// @name NewTranslationHandler - Creates new instance of translation handler
+// @param localizationUseCase - localization service instance
+// @returns - new translation handler instance
+func NewTranslationHandler(localizationUseCase *usecases.LocalizationUseCase) *TranslationHandler {
+	return &TranslationHandler{localizationUseCase: localizationUseCase}
+}
+
+// @name localizeContents - translates content metadata for the caller
+// @param c - gin context with the locales set by LocaleMiddleware
+// @param localizer - localization service instance
+// @param contents - content to translate in place
+// @returns - error when translations could not be loaded
+// @dev - a signed in user's preferred locale is tried before Accept-Language
+func localizeContents(c *gin.Context, localizer *usecases.LocalizationUseCase, contents ...*domain.Content) error {
+	return localizer.LocalizeContents(c.Request.Context(), optionalUserID(c), c.GetStringSlice("locales"), contents...)
+}
+
+// @name localizePlans - translates plan names and descriptions for the caller
+// @param c - gin context with the locales set by LocaleMiddleware
+// @param localizer - localization service instance
+// @param plans - plans to translate in place
+// @returns - error when translations could not be loaded
+func localizePlans(c *gin.Context, localizer *usecases.LocalizationUseCase, plans ...*domain.Plan) error {
+	return localizer.LocalizePlans(c.Request.Context(), optionalUserID(c), c.GetStringSlice("locales"), plans...)
+}
+
+// @name optionalUserID - reads the user ID of signed in callers on public routes
+// @param c - gin context
+// @returns - user ID or nil for anonymous requests
+func optionalUserID(c *gin.Context) *uuid.UUID {
+	id, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// @name ListContentTranslations - Admin API to list every translation of a content
+// @param c - gin context
+// @returns - translations ordered by locale
+func (h *TranslationHandler) ListContentTranslations(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	translations, err := h.localizationUseCase.ListContentTranslations(c.Request.Context(), contentID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+// @name PutContentTranslation - Admin API to create or replace a content translation
+// @param c - gin context
+// @returns - stored translation
+// @dev - empty fields fall back to the next locale in the viewer's chain
+func (h *TranslationHandler) PutContentTranslation(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	var input usecases.ContentTranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	translation, err := h.localizationUseCase.PutContentTranslation(c.Request.Context(), contentID, c.Param("locale"), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+// @name DeleteContentTranslation - Admin API to remove a content translation
+// @param c - gin context
+// @returns - success message
+func (h *TranslationHandler) DeleteContentTranslation(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	if err := h.localizationUseCase.DeleteContentTranslation(c.Request.Context(), contentID, c.Param("locale")); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "translation deleted successfully"})
+}
+
+// @name ListPlanTranslations - Admin API to list every translation of a plan
+// @param c - gin context
+// @returns - translations ordered by locale
+func (h *TranslationHandler) ListPlanTranslations(c *gin.Context) {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid plan ID"})
+		return
+	}
+	translations, err := h.localizationUseCase.ListPlanTranslations(c.Request.Context(), planID)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+// @name PutPlanTranslation - Admin API to create or replace a plan translation
+// @param c - gin context
+// @returns - stored translation
+func (h *TranslationHandler) PutPlanTranslation(c *gin.Context) {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid plan ID"})
+		return
+	}
+	var input usecases.PlanTranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	translation, err := h.localizationUseCase.PutPlanTranslation(c.Request.Context(), planID, c.Param("locale"), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+// @name DeletePlanTranslation - Admin API to remove a plan translation
+// @param c - gin context
+// @returns - success message
+func (h *TranslationHandler) DeletePlanTranslation(c *gin.Context) {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid plan ID"})
+		return
+	}
+	if err := h.localizationUseCase.DeletePlanTranslation(c.Request.Context(), planID, c.Param("locale")); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "translation deleted successfully"})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type TrendingHandler struct {
+	trendingUseCase     *usecases.TrendingUseCase
+	localizationUseCase *usecases.LocalizationUseCase
+}
+
+type TrendingOutput struct {
+	Rank          int               `json:"rank"`
+	Content       OpenContentOutput `json:"content"`
+	UniqueViewers int64             `json:"unique_viewers"`
+	Completions   int64             `json:"completions"`
+	WatchSeconds  int64             `json:"watch_seconds"`
+}
+
+// @name NewTrendingHandler - Creates new instance of trending handler
+// @param trendingUseCase - trending service instance
+// @param localizationUseCase - translates titles and descriptions for the viewer
+// @returns - new trending handler instance
+func NewTrendingHandler(trendingUseCase *usecases.TrendingUseCase, localizationUseCase *usecases.LocalizationUseCase) *TrendingHandler {
+	return &TrendingHandler{trendingUseCase: trendingUseCase, localizationUseCase: localizationUseCase}
+}
+
+// @name GetTrending - Open API to get the most watched titles of a rolling window
+// @param c - gin context
+// @query window - day or week, defaults to week
+// @query limit - chart size, defaults to 10
+// @returns - ranked content with viewer, completion and watch time totals
+// @dev - charts are regional when the viewer's country is known
+func (h *TrendingHandler) GetTrending(c *gin.Context) {
+	window := domain.TrendingWindow(c.DefaultQuery("window", string(domain.TrendingWindowWeek)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	activity, err := h.trendingUseCase.GetTrending(c.Request.Context(), window, c.GetString("country"), limit)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	contents := make([]*domain.Content, 0, len(activity))
+	for _, entry := range activity {
+		if entry.Content != nil {
+			contents = append(contents, entry.Content)
+		}
+	}
+	if err := localizeContents(c, h.localizationUseCase, contents...); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	outputs := make([]TrendingOutput, 0, len(activity))
+	for _, entry := range activity {
+		if entry.Content == nil {
+			continue
+		}
+		outputs = append(outputs, TrendingOutput{
+			Rank:          len(outputs) + 1,
+			Content:       toOpenContentOutput(entry.Content),
+			UniqueViewers: entry.UniqueViewers,
+			Completions:   entry.Completions,
+			WatchSeconds:  entry.WatchSeconds,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"window": window, "items": outputs})
+}
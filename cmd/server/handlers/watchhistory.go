@@ -2,22 +2,25 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/cursor"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type WatchHistoryHandler struct {
 	watchHistoryUseCase *usecases.WatchHistoryUseCase
+	cursorCodec         *cursor.Codec
 }
 
 // @name NewWatchHistoryHandler - Creates new instance of watch history handler
 // @param watchHistoryUseCase - watch history service instance
+// @param cursorCodec - signs and verifies pagination cursors
 // @returns - new instance of watch history handler
-func NewWatchHistoryHandler(watchHistoryUseCase *usecases.WatchHistoryUseCase) *WatchHistoryHandler {
-	return &WatchHistoryHandler{watchHistoryUseCase: watchHistoryUseCase}
+func NewWatchHistoryHandler(watchHistoryUseCase *usecases.WatchHistoryUseCase, cursorCodec *cursor.Codec) *WatchHistoryHandler {
+	return &WatchHistoryHandler{watchHistoryUseCase: watchHistoryUseCase, cursorCodec: cursorCodec}
 }
 
 // @name CreateOrUpdateWatchHistory - Create/Update user's watch history
@@ -37,7 +40,7 @@ func (h *WatchHistoryHandler) CreateOrUpdateWatchHistory(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	watchHistory, err := h.watchHistoryUseCase.CreateOrUpdateWatchHistory(c.Request.Context(), userID, input)
+	watchHistory, err := h.watchHistoryUseCase.CreateOrUpdateWatchHistory(c.Request.Context(), userID, c.GetString("country"), input)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -45,18 +48,49 @@ func (h *WatchHistoryHandler) CreateOrUpdateWatchHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, watchHistory)
 }
 
+// @name SyncWatchHistory - Uploads progress a device recorded while offline
+// @param c - gin context
+// @returns - per event outcome and the merged watch history of every synced title
+// @dev - last writer wins by client_time, stale and invalid events are reported
+//
+//	per event instead of failing the batch
+func (h *WatchHistoryHandler) SyncWatchHistory(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	var input usecases.SyncWatchHistoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := h.watchHistoryUseCase.SyncWatchHistory(c.Request.Context(), userID, c.GetString("country"), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // @name GetWatchHistory - Get's user's watch history
 // @param c - gin context
-// @returns - user's recent watch history in desc order of time
+// @query sort - last_watched_at or created_at, "-" prefix for descending, defaults to -last_watched_at
+// @query cursor - next_cursor of the previous page
+// @query limit - page size, defaults to 20 and is capped at 100
+// @returns - user's watch history with next_cursor, null on the last page
 func (h *WatchHistoryHandler) GetWatchHistory(c *gin.Context) {
 	userID, err := uuid.Parse(c.GetString("userID"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
 		return
 	}
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	histories, total, err := h.watchHistoryUseCase.GetWatchHistory(c.Request.Context(), userID, limit, offset)
+	page, err := parsePage(c, h.cursorCodec, "watch_history", repositories.SortLastWatchedAt, true)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	histories, total, next, err := h.watchHistoryUseCase.GetWatchHistory(c.Request.Context(), userID, page)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -64,8 +98,7 @@ func (h *WatchHistoryHandler) GetWatchHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"watch_history": histories,
 		"total":         total,
-		"limit":         limit,
-		"offset":        offset,
+		"next_cursor":   nextCursor(h.cursorCodec, "watch_history", page, next),
 	})
 }
 
@@ -89,6 +122,7 @@ func (h *WatchHistoryHandler) GetContinueWatching(c *gin.Context) {
 // @name UpdateProgress - Updates user's progress on a given content
 // @param c - gin context
 // @returns - newly updated watch history for single content
+// @dev - rejected once the content is no longer available to the user, like any heartbeat
 func (h *WatchHistoryHandler) UpdateProgress(c *gin.Context) {
 	userID, err := uuid.Parse(c.GetString("userID"))
 	if err != nil {
@@ -107,7 +141,7 @@ func (h *WatchHistoryHandler) UpdateProgress(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	watchHistory, err := h.watchHistoryUseCase.UpdateProgress(c.Request.Context(), userID, historyID, *input.WatchedSeconds)
+	watchHistory, err := h.watchHistoryUseCase.UpdateProgress(c.Request.Context(), userID, historyID, c.GetString("country"), *input.WatchedSeconds)
 	if err != nil {
 		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WatchlistHandler struct {
+	watchlistUseCase *usecases.WatchlistUseCase
+}
+
+// @name NewWatchlistHandler - Creates new instance of watchlist handler
+// @param watchlistUseCase - watchlist service instance
+// @returns - new watchlist handler instance
+func NewWatchlistHandler(watchlistUseCase *usecases.WatchlistUseCase) *WatchlistHandler {
+	return &WatchlistHandler{watchlistUseCase: watchlistUseCase}
+}
+
+// @name GetWatchlist - Protected API to get the user's watchlist in their saved order
+// @param c - gin context
+// @returns - watchlist entries with content and playback progress
+// @dev - titles unavailable in the viewer's region or window are hidden, not removed
+func (h *WatchlistHandler) GetWatchlist(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	entries, err := h.watchlistUseCase.GetWatchlist(c.Request.Context(), userID, c.GetString("country"))
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": entries, "total": len(entries)})
+}
+
+// @name AddToWatchlist - Protected API to append content to the user's watchlist
+// @param c - gin context
+// @returns - newly created watchlist item
+func (h *WatchlistHandler) AddToWatchlist(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	var input usecases.AddToWatchlistInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	item, err := h.watchlistUseCase.AddToWatchlist(c.Request.Context(), userID, c.GetString("country"), input)
+	if err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// @name RemoveFromWatchlist - Protected API to remove content from the user's watchlist
+// @param c - gin context
+// @returns - removal confirmation message
+func (h *WatchlistHandler) RemoveFromWatchlist(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("contentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content ID"})
+		return
+	}
+	if err := h.watchlistUseCase.RemoveFromWatchlist(c.Request.Context(), userID, contentID); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "removed from watchlist"})
+}
+
+// @name ReorderWatchlist - Protected API to set a new order for the user's watchlist
+// @param c - gin context
+// @returns - reorder confirmation message
+// @dev - content_ids must list every item exactly once, first entry goes on top
+func (h *WatchlistHandler) ReorderWatchlist(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	var input usecases.ReorderWatchlistInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.watchlistUseCase.ReorderWatchlist(c.Request.Context(), userID, input); err != nil {
+		c.JSON(getErrorStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "watchlist reordered"})
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/cmd/server/handlers"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/cmd/server/middleware"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/config"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories/postgres"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/cursor"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/signedurl"
 
 	"github.com/gin-gonic/gin"
 )
@@ -41,7 +44,27 @@ func main() {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 
+	progressBuffer := infrastructure.NewProgressBuffer(cache)
 	jwtService := infrastructure.NewJWTService(cfg.JWTSecret, cfg.JWTSauce, cfg.JWTExpiration)
+	eventBus := infrastructure.NewEventBus()
+	urlSigner := signedurl.NewSigner([]byte(cfg.PlaybackSigningKey))
+	cursorCodec := cursor.NewCodec([]byte(cfg.CursorSigningKey))
+	objectStorage, err := infrastructure.NewObjectStorage(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize object storage: %v", err)
+	}
+	geoResolver, err := infrastructure.NewGeoResolver(cfg.GeoCountryHeader, cfg.GeoIPDatabase, cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to initialize geo resolver: %v", err)
+	}
+	completionPolicies, err := domain.ParseCompletionPolicies(domain.CompletionPolicy{
+		Percentage:       float64(cfg.CompletionPercentage),
+		SecondsRemaining: cfg.CompletionSecondsRemaining,
+		UseCreditsMarker: cfg.CompletionCreditsMarker,
+	}, cfg.CompletionPolicies)
+	if err != nil {
+		log.Fatalf("Invalid completion policies: %v", err)
+	}
 
 	// Repositories Setup
 	userRepo := postgres.NewUserRepository(db)
@@ -49,32 +72,98 @@ func main() {
 	planRepo := postgres.NewPlanRepository(db)
 	subscriptionRepo := postgres.NewSubscriptionRepository(db)
 	watchHistoryRepo := postgres.NewWatchHistoryRepository(db)
+	personRepo := postgres.NewPersonRepository(db)
+	renditionRepo := postgres.NewRenditionRepository(db)
+	trackRepo := postgres.NewTrackRepository(db)
+	reviewRepo := postgres.NewReviewRepository(db)
+	watchlistRepo := postgres.NewWatchlistRepository(db)
+	recommendationRepo := postgres.NewRecommendationRepository(db)
+	trendingRepo := postgres.NewTrendingRepository(db)
+	collectionRepo := postgres.NewCollectionRepository(db)
+	revisionRepo := postgres.NewContentRevisionRepository(db)
+	importJobRepo := postgres.NewImportJobRepository(db)
+	translationRepo := postgres.NewTranslationRepository(db)
 
 	// Usecases (Services) Setup
 	authUseCase := usecases.NewAuthUseCase(userRepo, jwtService, cache, cfg.JWTExpiration)
 	userUseCase := usecases.NewUserUseCase(userRepo, subscriptionRepo)
-	contentUseCase := usecases.NewContentUseCase(contentRepo, subscriptionRepo, userRepo)
+	contentUseCase := usecases.NewContentUseCase(contentRepo, subscriptionRepo, userRepo, revisionRepo)
 	planUseCase := usecases.NewPlanUseCase(planRepo)
 	subscriptionUseCase := usecases.NewSubscriptionUseCase(subscriptionRepo, planRepo, userRepo)
-	watchHistoryUseCase := usecases.NewWatchHistoryUseCase(watchHistoryRepo, contentRepo, subscriptionRepo)
+	watchHistoryUseCase := usecases.NewWatchHistoryUseCase(watchHistoryRepo, contentRepo, subscriptionRepo, watchlistRepo, completionPolicies, progressBuffer)
+	personUseCase := usecases.NewPersonUseCase(personRepo, contentRepo, subscriptionRepo)
+	playbackUseCase := usecases.NewPlaybackUseCase(contentUseCase, renditionRepo, trackRepo, subscriptionRepo, urlSigner, time.Duration(cfg.PlaybackURLTTL)*time.Second)
+	renditionUseCase := usecases.NewRenditionUseCase(renditionRepo, contentRepo)
+	trackUseCase := usecases.NewTrackUseCase(trackRepo, contentRepo, urlSigner)
+	mediaUseCase := usecases.NewMediaUseCase(objectStorage, contentUseCase, contentRepo, userRepo)
+	reviewUseCase := usecases.NewReviewUseCase(reviewRepo, watchHistoryRepo)
+	watchlistUseCase := usecases.NewWatchlistUseCase(watchlistRepo, contentRepo, watchHistoryRepo)
+	recommendationUseCase := usecases.NewRecommendationUseCase(recommendationRepo, watchHistoryRepo, subscriptionRepo, cache)
+	trendingUseCase := usecases.NewTrendingUseCase(trendingRepo, cache)
+	statsUseCase := usecases.NewStatsUseCase(watchHistoryRepo, cache)
+	collectionUseCase := usecases.NewCollectionUseCase(collectionRepo, contentRepo)
+	homeUseCase := usecases.NewHomeUseCase(collectionRepo, contentRepo, watchHistoryRepo, subscriptionRepo, trendingUseCase)
+	catalogUseCase := usecases.NewCatalogUseCase(contentUseCase, contentRepo, importJobRepo)
+	localizationUseCase := usecases.NewLocalizationUseCase(translationRepo, contentRepo, planRepo, userRepo, cfg.DefaultLocale)
+
+	// Background Jobs Setup
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	logContentEvent := func(ctx context.Context, event domain.ContentEvent) {
+		log.Printf("Content %s: %s", event.Type, event.ContentID)
+	}
+	// The home page has no cache of its own, its trending rail is served from the trending cache
+	for _, eventType := range []domain.ContentEventType{domain.ContentEventPublished, domain.ContentEventExpired} {
+		eventBus.Subscribe(eventType, logContentEvent)
+		eventBus.Subscribe(eventType, trendingUseCase.HandleContentEvent)
+		eventBus.Subscribe(eventType, recommendationUseCase.HandleContentEvent)
+	}
+	availabilityScheduler := usecases.NewAvailabilityScheduler(contentRepo, eventBus, cache)
+	go availabilityScheduler.Start(jobsCtx, time.Duration(cfg.AvailabilityInterval)*time.Second)
+	recommendationJob := usecases.NewRecommendationJob(recommendationRepo, cache)
+	go recommendationJob.Start(jobsCtx, time.Duration(cfg.RecommendationInterval)*time.Second)
+	trashPurgeJob := usecases.NewTrashPurgeJob(contentRepo, planRepo, time.Duration(cfg.TrashRetentionDays)*24*time.Hour)
+	go trashPurgeJob.Start(jobsCtx, time.Duration(cfg.TrashPurgeInterval)*time.Second)
+	go catalogUseCase.StartImportSweep(jobsCtx, time.Minute)
+	progressFlushJob := usecases.NewProgressFlushJob(progressBuffer, watchHistoryRepo, cfg.ProgressFlushBatchUsers)
+	go progressFlushJob.Start(jobsCtx, time.Duration(cfg.ProgressFlushInterval)*time.Second)
 
 	// Handler (Controllers) Setup
 	authHandler := handlers.NewAuthHandler(authUseCase)
 	userHandler := handlers.NewUserHandler(userUseCase)
-	contentHandler := handlers.NewContentHandler(contentUseCase)
-	planHandler := handlers.NewPlanHandler(planUseCase)
+	contentHandler := handlers.NewContentHandler(contentUseCase, localizationUseCase, cursorCodec)
+	planHandler := handlers.NewPlanHandler(planUseCase, localizationUseCase)
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionUseCase)
-	watchHistoryHandler := handlers.NewWatchHistoryHandler(watchHistoryUseCase)
+	watchHistoryHandler := handlers.NewWatchHistoryHandler(watchHistoryUseCase, cursorCodec)
+	personHandler := handlers.NewPersonHandler(personUseCase, localizationUseCase)
+	playbackHandler := handlers.NewPlaybackHandler(playbackUseCase)
+	renditionHandler := handlers.NewRenditionHandler(renditionUseCase)
+	trackHandler := handlers.NewTrackHandler(trackUseCase)
+	mediaHandler := handlers.NewMediaHandler(mediaUseCase)
+	reviewHandler := handlers.NewReviewHandler(reviewUseCase)
+	watchlistHandler := handlers.NewWatchlistHandler(watchlistUseCase)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationUseCase)
+	trendingHandler := handlers.NewTrendingHandler(trendingUseCase, localizationUseCase)
+	statsHandler := handlers.NewStatsHandler(statsUseCase, localizationUseCase)
+	collectionHandler := handlers.NewCollectionHandler(collectionUseCase, homeUseCase, localizationUseCase)
+	catalogHandler := handlers.NewCatalogHandler(catalogUseCase)
+	translationHandler := handlers.NewTranslationHandler(localizationUseCase)
 
 	// Server w/ Routes Setup
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	router := gin.Default()
+	// Only listed proxies may supply the client IP used for geo and rate limits
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
 	router.Use(middleware.CORS())
 	router.Use(middleware.ErrorHandler())
+	router.Use(middleware.GeoMiddleware(geoResolver))
+	router.Use(middleware.LocaleMiddleware())
 
-	setupRoutes(router, cfg, jwtService, cache, authHandler, userHandler, contentHandler, planHandler, subscriptionHandler, watchHistoryHandler)
+	setupRoutes(router, cfg, jwtService, cache, authHandler, userHandler, contentHandler, planHandler, subscriptionHandler, watchHistoryHandler, personHandler, playbackHandler, renditionHandler, trackHandler, mediaHandler, reviewHandler, watchlistHandler, recommendationHandler, trendingHandler, collectionHandler, catalogHandler, translationHandler, statsHandler)
 
 	srv := &http.Server{
 		Addr:           fmt.Sprintf(":%s", cfg.Port),
@@ -97,11 +186,18 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if err := catalogUseCase.WaitForImports(ctx); err != nil {
+		log.Printf("Catalog imports still running at shutdown: %v", err)
+	}
+	if err := progressFlushJob.Run(ctx); err != nil {
+		log.Printf("Final progress flush failed: %v", err)
+	}
 	log.Println("Server exited")
 }
 
@@ -116,12 +212,28 @@ func setupRoutes(
 	planHandler *handlers.PlanHandler,
 	subscriptionHandler *handlers.SubscriptionHandler,
 	watchHistoryHandler *handlers.WatchHistoryHandler,
+	personHandler *handlers.PersonHandler,
+	playbackHandler *handlers.PlaybackHandler,
+	renditionHandler *handlers.RenditionHandler,
+	trackHandler *handlers.TrackHandler,
+	mediaHandler *handlers.MediaHandler,
+	reviewHandler *handlers.ReviewHandler,
+	watchlistHandler *handlers.WatchlistHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	trendingHandler *handlers.TrendingHandler,
+	collectionHandler *handlers.CollectionHandler,
+	catalogHandler *handlers.CatalogHandler,
+	translationHandler *handlers.TranslationHandler,
+	statsHandler *handlers.StatsHandler,
 ) {
 	// Default Routes
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 	router.NoRoute(middleware.NoRouteMiddleware())
+	if cfg.StorageDriver == "local" {
+		router.Static("/media", cfg.StorageLocalDir)
+	}
 
 	// Public Routes
 	v1 := router.Group("/api/v1")
@@ -135,8 +247,20 @@ func setupRoutes(
 		content := public.Group("/content")
 		{
 			content.GET("", contentHandler.ListContent)
+			content.GET("/leaving-soon", contentHandler.ListLeavingSoon)
+			content.GET("/trending", trendingHandler.GetTrending)
 			content.GET("/:id", contentHandler.GetContent)
+			content.GET("/:id/credits", personHandler.GetContentCredits)
+			content.GET("/:id/subtitles/:trackFile", trackHandler.GetSubtitleFile)
+			content.GET("/:id/reviews", reviewHandler.ListContentReviews)
 		}
+		people := public.Group("/people")
+		people.Use(middleware.OptionalAuthMiddleware(jwtService, cache))
+		{
+			people.GET("", personHandler.ListPeople)
+			people.GET("/:id", personHandler.GetPerson)
+		}
+		public.GET("/home", middleware.OptionalAuthMiddleware(jwtService, cache), collectionHandler.GetHome)
 		plans := public.Group("/plans")
 		{
 			plans.GET("", planHandler.ListPlans)
@@ -161,7 +285,10 @@ func setupRoutes(
 		{
 			users.GET("/profile", userHandler.GetProfile)
 			users.PUT("/profile", userHandler.UpdateProfile)
+			users.POST("/profile/picture", mediaHandler.UploadAvatar)
 			users.GET("/subscription-history", userHandler.GetSubscriptionHistory)
+			users.GET("/stats", statsHandler.GetStats)
+			users.GET("/stats/year-in-review", statsHandler.GetYearInReview)
 		}
 		subscriptions := protected.Group("/subscriptions")
 		{
@@ -171,13 +298,31 @@ func setupRoutes(
 			subscriptions.POST("/:id/cancel", subscriptionHandler.CancelSubscription)
 			subscriptions.POST("/:id/renew", subscriptionHandler.RenewSubscription)
 		}
+		content := protected.Group("/content")
+		{
+			content.POST("/:id/playback", playbackHandler.CreatePlaybackSession)
+			content.GET("/:id/playback/master.m3u8", playbackHandler.GetHLSManifest)
+			content.GET("/:id/playback/manifest.mpd", playbackHandler.GetDASHManifest)
+			content.GET("/:id/review", reviewHandler.GetMyReview)
+			content.PUT("/:id/review", reviewHandler.RateContent)
+			content.DELETE("/:id/review", reviewHandler.DeleteMyReview)
+		}
 		watchHistory := protected.Group("/watch-history")
 		{
 			watchHistory.POST("", watchHistoryHandler.CreateOrUpdateWatchHistory)
+			watchHistory.POST("/sync", watchHistoryHandler.SyncWatchHistory)
 			watchHistory.GET("", watchHistoryHandler.GetWatchHistory)
 			watchHistory.GET("/continue-watching", watchHistoryHandler.GetContinueWatching)
 			watchHistory.PUT("/:id", watchHistoryHandler.UpdateProgress)
 		}
+		watchlist := protected.Group("/watchlist")
+		{
+			watchlist.GET("", watchlistHandler.GetWatchlist)
+			watchlist.POST("", watchlistHandler.AddToWatchlist)
+			watchlist.PUT("/order", watchlistHandler.ReorderWatchlist)
+			watchlist.DELETE("/:contentId", watchlistHandler.RemoveFromWatchlist)
+		}
+		protected.GET("/recommendations", recommendationHandler.GetRecommendations)
 
 		// Admin Routes
 		adminMiddleware := middleware.AdminMiddleware()
@@ -187,14 +332,68 @@ func setupRoutes(
 			adminContent := admin.Group("/content")
 			{
 				adminContent.POST("", contentHandler.CreateContent)
+				adminContent.PUT("/territories", contentHandler.UpdateTerritories)
+				adminContent.GET("/trash", contentHandler.ListTrash)
 				adminContent.PUT("/:id", contentHandler.UpdateContent)
 				adminContent.DELETE("/:id", contentHandler.DeleteContent)
+				adminContent.POST("/:id/restore", contentHandler.RestoreContent)
+				adminContent.PUT("/:id/markers", contentHandler.UpdateMarkers)
+				adminContent.PUT("/:id/draft", contentHandler.SaveDraft)
+				adminContent.DELETE("/:id/draft", contentHandler.DiscardDraft)
+				adminContent.GET("/:id/revisions", contentHandler.ListRevisions)
+				adminContent.POST("/:id/revisions/:rev/restore", contentHandler.RestoreRevision)
+				adminContent.GET("/:id/translations", translationHandler.ListContentTranslations)
+				adminContent.PUT("/:id/translations/:locale", translationHandler.PutContentTranslation)
+				adminContent.DELETE("/:id/translations/:locale", translationHandler.DeleteContentTranslation)
+				adminContent.POST("/:id/artwork", mediaHandler.UploadArtwork)
+				adminContent.POST("/:id/credits", personHandler.AddCredit)
+				adminContent.DELETE("/:id/credits/:creditId", personHandler.RemoveCredit)
+				adminContent.GET("/:id/renditions", renditionHandler.ListRenditions)
+				adminContent.POST("/:id/renditions", renditionHandler.AddRendition)
+				adminContent.DELETE("/:id/renditions/:renditionId", renditionHandler.RemoveRendition)
+				adminContent.GET("/:id/tracks", trackHandler.ListTracks)
+				adminContent.POST("/:id/subtitles", trackHandler.AddSubtitle)
+				adminContent.DELETE("/:id/subtitles/:trackId", trackHandler.RemoveSubtitle)
+				adminContent.POST("/:id/audio-tracks", trackHandler.AddAudioTrack)
+				adminContent.DELETE("/:id/audio-tracks/:trackId", trackHandler.RemoveAudioTrack)
+			}
+			adminPeople := admin.Group("/people")
+			{
+				adminPeople.POST("", personHandler.CreatePerson)
+				adminPeople.PUT("/:id", personHandler.UpdatePerson)
+				adminPeople.DELETE("/:id", personHandler.DeletePerson)
+			}
+			adminReviews := admin.Group("/reviews")
+			{
+				adminReviews.GET("", reviewHandler.ListModerationQueue)
+				adminReviews.PUT("/:id/moderation", reviewHandler.ModerateReview)
+				adminReviews.DELETE("/:id", reviewHandler.DeleteReview)
+			}
+			adminCollections := admin.Group("/collections")
+			{
+				adminCollections.POST("", collectionHandler.CreateCollection)
+				adminCollections.GET("", collectionHandler.ListCollections)
+				adminCollections.GET("/:id", collectionHandler.GetCollection)
+				adminCollections.PUT("/:id", collectionHandler.UpdateCollection)
+				adminCollections.PUT("/:id/items", collectionHandler.SetCollectionItems)
+				adminCollections.DELETE("/:id", collectionHandler.DeleteCollection)
+			}
+			adminCatalog := admin.Group("/catalog")
+			{
+				adminCatalog.POST("/import", catalogHandler.ImportCatalog)
+				adminCatalog.GET("/imports/:id", catalogHandler.GetImportJob)
+				adminCatalog.GET("/export", catalogHandler.ExportCatalog)
 			}
 			adminPlans := admin.Group("/plans")
 			{
 				adminPlans.POST("", planHandler.CreatePlan)
+				adminPlans.GET("/trash", planHandler.ListTrash)
 				adminPlans.PUT("/:id", planHandler.UpdatePlan)
 				adminPlans.DELETE("/:id", planHandler.DeletePlan)
+				adminPlans.POST("/:id/restore", planHandler.RestorePlan)
+				adminPlans.GET("/:id/translations", translationHandler.ListPlanTranslations)
+				adminPlans.PUT("/:id/translations/:locale", translationHandler.PutPlanTranslation)
+				adminPlans.DELETE("/:id/translations/:locale", translationHandler.DeletePlanTranslation)
 			}
 		}
 	}
@@ -58,6 +58,32 @@ func AuthMiddleware(jwtService *infrastructure.JWTService, cache *infrastructure
 	}
 }
 
+// OptionalAuthMiddleware identifies the caller when a valid access token is sent
+// but lets anonymous requests through, for public routes that tailor results by access
+func OptionalAuthMiddleware(jwtService *infrastructure.JWTService, cache *infrastructure.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.Next()
+			return
+		}
+		claims, err := jwtService.ValidateToken(parts[1], false)
+		if err != nil {
+			c.Next()
+			return
+		}
+		stored, err := cache.Get(c, "token:"+claims.UserID.String())
+		if err != nil || stored != parts[1] {
+			c.Next()
+			return
+		}
+		c.Set("userID", claims.UserID.String())
+		c.Set("userEmail", claims.Email)
+		c.Set("isAdmin", claims.IsAdmin)
+		c.Next()
+	}
+}
+
 // AdminMiddleware checks if user has admin privileges
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
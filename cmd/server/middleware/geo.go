@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+)
+
+// GeoMiddleware resolves the caller's country for regional licensing checks.
+// The client IP honours X-Forwarded-For and the country header is honoured only
+// from the configured trusted proxies.
+func GeoMiddleware(resolver *infrastructure.GeoResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("country", resolver.Country(c.Request, c.RemoteIP(), c.ClientIP()))
+		c.Next()
+	}
+}
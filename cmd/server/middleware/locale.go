@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware reads the caller's Accept-Language tags for localized metadata
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("locales", domain.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -3,50 +3,102 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Environment   string
-	Port          string
-	DatabaseURL   string
-	RedisHost     string
-	RedisPort     string
-	RedisPwd      string
-	RedisDB       int
-	JWTSecret     string
-	JWTSauce      string
-	JWTExpiration int
-	DBHost        string
-	DBPort        string
-	DBUser        string
-	DBPassword    string
-	DBName        string
-	DBSSLMode     string
-	RequestLimit  int
+	Environment                string
+	Port                       string
+	DatabaseURL                string
+	RedisHost                  string
+	RedisPort                  string
+	RedisPwd                   string
+	RedisDB                    int
+	JWTSecret                  string
+	JWTSauce                   string
+	JWTExpiration              int
+	DBHost                     string
+	DBPort                     string
+	DBUser                     string
+	DBPassword                 string
+	DBName                     string
+	DBSSLMode                  string
+	RequestLimit               int
+	AvailabilityInterval       int
+	RecommendationInterval     int
+	TrashRetentionDays         int
+	TrashPurgeInterval         int
+	ProgressFlushInterval      int
+	ProgressFlushBatchUsers    int
+	CompletionPercentage       int
+	CompletionSecondsRemaining int
+	CompletionCreditsMarker    bool
+	CompletionPolicies         string
+	TrustedProxies             []string
+	GeoCountryHeader           string
+	GeoIPDatabase              string
+	DefaultLocale              string
+	PlaybackSigningKey         string
+	PlaybackURLTTL             int
+	CursorSigningKey           string
+	StorageDriver              string
+	StorageLocalDir            string
+	StoragePublicURL           string
+	S3Endpoint                 string
+	S3Region                   string
+	S3Bucket                   string
+	S3AccessKeyID              string
+	S3SecretAccessKey          string
 }
 
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 	cfg := &Config{
-		Environment:   getEnv("ENVIRONMENT", "development"),
-		Port:          getEnv("PORT", "3000"),
-		DatabaseURL:   getEnv("DATABASE_URL", ""),
-		RedisHost:     getEnv("REDIS_HOST", "localhost"),
-		RedisPort:     getEnv("REDIS_PORT", "6379"),
-		RedisPwd:      getEnv("REDIS_PASSWORD", ""),
-		RedisDB:       getEnvAsInt("REDIS_DB", 0),
-		JWTSecret:     getEnv("JWT_SECRET", ""),
-		JWTSauce:      getEnv("JWT_SAUCE", ""),
-		JWTExpiration: getEnvAsInt("JWT_EXPIRATION", 1),
-		DBHost:        getEnv("DB_HOST", "localhost"),
-		DBPort:        getEnv("DB_PORT", "5432"),
-		DBUser:        getEnv("DB_USERNAME", "postgres"),
-		DBPassword:    getEnv("DB_PASSWORD", "postgres"),
-		DBName:        getEnv("DB_NAME", "aub-task"),
-		DBSSLMode:     getEnv("DB_SSLMODE", "disable"),
-		RequestLimit:  getEnvAsInt("RATE_LIMIT", 100),
+		Environment:                getEnv("ENVIRONMENT", "development"),
+		Port:                       getEnv("PORT", "3000"),
+		DatabaseURL:                getEnv("DATABASE_URL", ""),
+		RedisHost:                  getEnv("REDIS_HOST", "localhost"),
+		RedisPort:                  getEnv("REDIS_PORT", "6379"),
+		RedisPwd:                   getEnv("REDIS_PASSWORD", ""),
+		RedisDB:                    getEnvAsInt("REDIS_DB", 0),
+		JWTSecret:                  getEnv("JWT_SECRET", ""),
+		JWTSauce:                   getEnv("JWT_SAUCE", ""),
+		JWTExpiration:              getEnvAsInt("JWT_EXPIRATION", 1),
+		DBHost:                     getEnv("DB_HOST", "localhost"),
+		DBPort:                     getEnv("DB_PORT", "5432"),
+		DBUser:                     getEnv("DB_USERNAME", "postgres"),
+		DBPassword:                 getEnv("DB_PASSWORD", "postgres"),
+		DBName:                     getEnv("DB_NAME", "aub-task"),
+		DBSSLMode:                  getEnv("DB_SSLMODE", "disable"),
+		RequestLimit:               getEnvAsInt("RATE_LIMIT", 100),
+		AvailabilityInterval:       getEnvAsInt("AVAILABILITY_INTERVAL", 60),
+		RecommendationInterval:     getEnvAsInt("RECOMMENDATION_INTERVAL", 3600),
+		TrashRetentionDays:         getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		TrashPurgeInterval:         getEnvAsInt("TRASH_PURGE_INTERVAL", 3600),
+		ProgressFlushInterval:      getEnvAsInt("PROGRESS_FLUSH_INTERVAL", 30),
+		ProgressFlushBatchUsers:    getEnvAsInt("PROGRESS_FLUSH_BATCH_USERS", 200),
+		CompletionPercentage:       getEnvAsInt("COMPLETION_PERCENTAGE", 90),
+		CompletionSecondsRemaining: getEnvAsInt("COMPLETION_SECONDS_REMAINING", 0),
+		CompletionCreditsMarker:    getEnv("COMPLETION_CREDITS_MARKER", "true") == "true",
+		CompletionPolicies:         getEnv("COMPLETION_POLICIES", ""),
+		TrustedProxies:             getEnvAsList("TRUSTED_PROXIES"),
+		GeoCountryHeader:           getEnv("GEO_COUNTRY_HEADER", ""),
+		GeoIPDatabase:              getEnv("GEOIP_DATABASE", ""),
+		DefaultLocale:              getEnv("DEFAULT_LOCALE", "en"),
+		PlaybackSigningKey:         getEnv("PLAYBACK_SIGNING_KEY", ""),
+		PlaybackURLTTL:             getEnvAsInt("PLAYBACK_URL_TTL", 3600),
+		CursorSigningKey:           getEnv("CURSOR_SIGNING_KEY", ""),
+		StorageDriver:              getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:            getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		StoragePublicURL:           getEnv("STORAGE_PUBLIC_URL", ""),
+		S3Endpoint:                 getEnv("S3_ENDPOINT", ""),
+		S3Region:                   getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                   getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:              getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:          getEnv("S3_SECRET_ACCESS_KEY", ""),
 	}
 	if cfg.DatabaseURL == "" {
 		cfg.DatabaseURL = fmt.Sprintf(
@@ -54,9 +106,34 @@ func Load() (*Config, error) {
 			cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode,
 		)
 	}
+	if cfg.StoragePublicURL == "" && cfg.StorageDriver == "local" {
+		cfg.StoragePublicURL = "/media"
+	}
 	if cfg.JWTSecret == "" && cfg.Environment == "production" {
 		return nil, fmt.Errorf("JWT_SECRET must be set in production")
 	}
+	if cfg.PlaybackSigningKey == "" && cfg.Environment == "production" {
+		return nil, fmt.Errorf("PLAYBACK_SIGNING_KEY must be set in production")
+	}
+	if cfg.CursorSigningKey == "" && cfg.Environment == "production" {
+		return nil, fmt.Errorf("CURSOR_SIGNING_KEY must be set in production")
+	}
+	positive := []struct {
+		key   string
+		value int
+	}{
+		{"AVAILABILITY_INTERVAL", cfg.AvailabilityInterval},
+		{"RECOMMENDATION_INTERVAL", cfg.RecommendationInterval},
+		{"TRASH_RETENTION_DAYS", cfg.TrashRetentionDays},
+		{"TRASH_PURGE_INTERVAL", cfg.TrashPurgeInterval},
+		{"PROGRESS_FLUSH_INTERVAL", cfg.ProgressFlushInterval},
+		{"PROGRESS_FLUSH_BATCH_USERS", cfg.ProgressFlushBatchUsers},
+	}
+	for _, setting := range positive {
+		if setting.value <= 0 {
+			return nil, fmt.Errorf("%s must be greater than zero", setting.key)
+		}
+	}
 	return cfg, nil
 }
 
@@ -69,9 +146,22 @@ func getEnv(key, defaultValue string) string {
 
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
-		var intValue int
-		fmt.Sscanf(value, "%d", &intValue)
+		intValue, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return defaultValue
+		}
 		return intValue
 	}
 	return defaultValue
 }
+
+// getEnvAsList splits a comma separated value, nil when unset
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
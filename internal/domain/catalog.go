@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// CatalogFormat is a file format for bulk catalog import and export
+type CatalogFormat string
+
+const (
+	CatalogFormatCSV   CatalogFormat = "csv"
+	CatalogFormatJSONL CatalogFormat = "jsonl"
+)
+
+func (f CatalogFormat) IsValid() bool {
+	return f == CatalogFormatCSV || f == CatalogFormatJSONL
+}
+
+// ImportRowError reports why a row of an import file was rejected, rows are
+// numbered from 1 and exclude the CSV header
+type ImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+// ImportRowErrors is persisted as a JSON array in row order
+type ImportRowErrors []ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (e *ImportRowErrors) Scan(value interface{}) error {
+	switch raw := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(raw), e)
+	case []byte:
+		return json.Unmarshal(raw, e)
+	default:
+		return fmt.Errorf("cannot scan %T into ImportRowErrors", value)
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type ContentType string
+
+const (
+	ContentTypeMovie   ContentType = "movie"
+	ContentTypeEpisode ContentType = "episode"
+	ContentTypeShort   ContentType = "short"
+)
+
+func (t ContentType) IsValid() bool {
+	switch t {
+	case ContentTypeMovie, ContentTypeEpisode, ContentTypeShort:
+		return true
+	}
+	return false
+}
+
+// CompletionPolicy decides when a viewing counts as finished. The credits
+// marker wins when enabled and present, otherwise either threshold completes.
+type CompletionPolicy struct {
+	Percentage       float64 `json:"percentage"`
+	SecondsRemaining int     `json:"seconds_remaining"`
+	UseCreditsMarker bool    `json:"use_credits_marker"`
+}
+
+var DefaultCompletionPolicy = CompletionPolicy{Percentage: 90, UseCreditsMarker: true}
+
+// Validate rejects policies that could never complete content without a credits marker
+func (p CompletionPolicy) Validate() error {
+	if p.Percentage < 0 || p.Percentage > 100 || p.SecondsRemaining < 0 {
+		return ErrInvalidCompletionPolicy
+	}
+	if p.Percentage == 0 && p.SecondsRemaining == 0 {
+		return ErrInvalidCompletionPolicy
+	}
+	return nil
+}
+
+func (p CompletionPolicy) IsComplete(watchedSeconds, totalSeconds int, content *Content) bool {
+	if totalSeconds <= 0 {
+		return false
+	}
+	if p.UseCreditsMarker && content != nil {
+		if credits := content.Markers.CreditsStartSeconds; credits != nil && *credits < totalSeconds {
+			return watchedSeconds >= *credits
+		}
+	}
+	if p.Percentage > 0 && float64(watchedSeconds)/float64(totalSeconds)*100 >= p.Percentage {
+		return true
+	}
+	return p.SecondsRemaining > 0 && totalSeconds-watchedSeconds <= p.SecondsRemaining
+}
+
+// CompletionPolicies holds the global policy and its per content type overrides
+type CompletionPolicies struct {
+	Default       CompletionPolicy
+	ByContentType map[ContentType]CompletionPolicy
+}
+
+func (p CompletionPolicies) For(contentType ContentType) CompletionPolicy {
+	if policy, ok := p.ByContentType[contentType]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// ParseCompletionPolicies reads per content type overrides from JSON such as
+// {"episode": {"seconds_remaining": 60}}. Omitted fields keep the global value.
+func ParseCompletionPolicies(global CompletionPolicy, overrides string) (CompletionPolicies, error) {
+	policies := CompletionPolicies{Default: global, ByContentType: map[ContentType]CompletionPolicy{}}
+	if err := global.Validate(); err != nil {
+		return policies, err
+	}
+	if strings.TrimSpace(overrides) == "" {
+		return policies, nil
+	}
+	var raw map[ContentType]struct {
+		Percentage       *float64 `json:"percentage"`
+		SecondsRemaining *int     `json:"seconds_remaining"`
+		UseCreditsMarker *bool    `json:"use_credits_marker"`
+	}
+	if err := json.Unmarshal([]byte(overrides), &raw); err != nil {
+		return policies, ErrInvalidCompletionPolicy
+	}
+	for contentType, override := range raw {
+		if !contentType.IsValid() {
+			return policies, ErrInvalidCompletionPolicy
+		}
+		policy := global
+		if override.Percentage != nil {
+			policy.Percentage = *override.Percentage
+		}
+		if override.SecondsRemaining != nil {
+			policy.SecondsRemaining = *override.SecondsRemaining
+		}
+		if override.UseCreditsMarker != nil {
+			policy.UseCreditsMarker = *override.UseCreditsMarker
+		}
+		if err := policy.Validate(); err != nil {
+			return policies, err
+		}
+		policies.ByContentType[contentType] = policy
+	}
+	return policies, nil
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ISO 3166-1 alpha-2 officially assigned codes
+const iso3166Alpha2 = `
+AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ
+BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ
+DE DJ DK DM DO DZ
+EC EE EG EH ER ES ET
+FI FJ FK FM FO FR
+GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY
+HK HM HN HR HT HU
+ID IE IL IM IN IO IQ IR IS IT
+JE JM JO JP
+KE KG KH KI KM KN KP KR KW KY KZ
+LA LB LC LI LK LR LS LT LU LV LY
+MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ
+NA NC NE NF NG NI NL NO NP NR NU NZ
+OM
+PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+QA
+RE RO RS RU RW
+SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ
+TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ
+UA UG UM US UY UZ
+VA VC VE VG VI VN VU
+WF WS
+YE YT
+ZA ZM ZW
+`
+
+var countryCodes = func() map[string]struct{} {
+	codes := make(map[string]struct{})
+	for _, code := range strings.Fields(iso3166Alpha2) {
+		codes[code] = struct{}{}
+	}
+	return codes
+}()
+
+func IsValidCountryCode(code string) bool {
+	_, ok := countryCodes[code]
+	return ok
+}
+
+// CountryCodes is persisted as a comma separated column so it can be matched with LIKE
+type CountryCodes []string
+
+func (c CountryCodes) Value() (driver.Value, error) {
+	return strings.Join(c, ","), nil
+}
+
+func (c *CountryCodes) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into CountryCodes", value)
+	}
+	if raw == "" {
+		*c = nil
+		return nil
+	}
+	*c = strings.Split(raw, ",")
+	return nil
+}
+
+func (c CountryCodes) Contains(code string) bool {
+	for _, existing := range c {
+		if existing == code {
+			return true
+		}
+	}
+	return false
+}
+
+func NormalizeCountryCodes(codes []string) (CountryCodes, error) {
+	normalized := make(CountryCodes, 0, len(codes))
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !IsValidCountryCode(code) {
+			return nil, ErrInvalidCountryCode
+		}
+		if !normalized.Contains(code) {
+			normalized = append(normalized, code)
+		}
+	}
+	return normalized, nil
+}
@@ -1,9 +1,12 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AccessLevel string
@@ -14,6 +17,81 @@ const (
 	AccessLevelPremium AccessLevel = "premium"
 )
 
+func (a AccessLevel) Allows(required AccessLevel) bool {
+	return a.rank() >= required.rank()
+}
+
+func (a AccessLevel) rank() int {
+	switch a {
+	case AccessLevelFree:
+		return 0
+	case AccessLevelBasic:
+		return 1
+	case AccessLevelPremium:
+		return 2
+	default:
+		return -1
+	}
+}
+
+type Resolution string
+
+const (
+	Resolution480p  Resolution = "480p"
+	Resolution720p  Resolution = "720p"
+	Resolution1080p Resolution = "1080p"
+	Resolution4K    Resolution = "4K"
+)
+
+// ParseResolution matches a resolution regardless of case, plans saved before
+// resolutions were validated hold values such as "4k"
+func ParseResolution(value string) (Resolution, error) {
+	for _, resolution := range []Resolution{Resolution480p, Resolution720p, Resolution1080p, Resolution4K} {
+		if strings.EqualFold(value, string(resolution)) {
+			return resolution, nil
+		}
+	}
+	return "", ErrInvalidResolution
+}
+
+func (r Resolution) IsValid() bool {
+	return r.rank() >= 0
+}
+
+func (r Resolution) AtMost(max Resolution) bool {
+	return r.IsValid() && r.rank() <= max.rank()
+}
+
+func (r Resolution) Dimensions() (width, height int) {
+	switch r {
+	case Resolution480p:
+		return 854, 480
+	case Resolution720p:
+		return 1280, 720
+	case Resolution1080p:
+		return 1920, 1080
+	case Resolution4K:
+		return 3840, 2160
+	default:
+		return 0, 0
+	}
+}
+
+func (r Resolution) rank() int {
+	switch r {
+	case Resolution480p:
+		return 0
+	case Resolution720p:
+		return 1
+	case Resolution1080p:
+		return 2
+	case Resolution4K:
+		return 3
+	default:
+		return -1
+	}
+}
+
 type SubscriptionStatus string
 
 const (
@@ -30,17 +108,26 @@ const (
 	WatchStatusCompleted WatchStatus = "completed"
 )
 
+type PersonRole string
+
+const (
+	PersonRoleActor    PersonRole = "actor"
+	PersonRoleDirector PersonRole = "director"
+	PersonRoleWriter   PersonRole = "writer"
+)
+
 type User struct {
-	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Email        string    `gorm:"uniqueIndex;not null" json:"email"`
-	PasswordHash string    `gorm:"not null" json:"-"`
-	Name         string    `gorm:"not null" json:"name"`
-	Bio          string    `json:"bio"`
-	Picture      string    `json:"picture"`
-	Phone        string    `json:"phone"`
-	IsAdmin      bool      `gorm:"default:false" json:"is_admin"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Email           string    `gorm:"uniqueIndex;not null" json:"email"`
+	PasswordHash    string    `gorm:"not null" json:"-"`
+	Name            string    `gorm:"not null" json:"name"`
+	Bio             string    `json:"bio"`
+	Picture         string    `json:"picture"`
+	Phone           string    `json:"phone"`
+	IsAdmin         bool      `gorm:"default:false" json:"is_admin"`
+	PreferredLocale string    `gorm:"type:varchar(35)" json:"preferred_locale,omitempty"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 func (User) TableName() string {
@@ -48,39 +135,115 @@ func (User) TableName() string {
 }
 
 type Content struct {
-	ID              uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Title           string      `gorm:"not null;index" json:"title"`
-	Description     string      `json:"description"`
-	AccessLevel     AccessLevel `gorm:"type:varchar(20);not null;index" json:"access_level"`
-	DurationSeconds int         `gorm:"not null" json:"duration_seconds"`
-	ThumbnailURL    string      `json:"thumbnail_url"`
-	TrailerURL      string      `json:"trailer_url"`
-	VideoURL        string      `json:"video_url"`
-	Published       bool        `gorm:"default:false;index" json:"published"`
-	CreatedAt       time.Time   `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ExternalID        *string        `gorm:"type:varchar(100);uniqueIndex" json:"external_id,omitempty"`
+	Title             string         `gorm:"not null;index" json:"title"`
+	Description       string         `json:"description"`
+	Locale            string         `gorm:"-" json:"locale,omitempty"`
+	AccessLevel       AccessLevel    `gorm:"type:varchar(20);not null;index" json:"access_level"`
+	ContentType       ContentType    `gorm:"type:varchar(20);not null;default:'movie';index" json:"content_type"`
+	Genre             string         `gorm:"type:varchar(50);not null;default:'';index" json:"genre"`
+	DurationSeconds   int            `gorm:"not null" json:"duration_seconds"`
+	ThumbnailURL      string         `json:"thumbnail_url"`
+	ThumbnailVariants ImageVariants  `gorm:"type:jsonb;not null;default:'[]'" json:"thumbnail_variants"`
+	ThumbnailBlurHash string         `gorm:"type:varchar(64)" json:"thumbnail_blurhash"`
+	ThumbnailColor    string         `gorm:"type:varchar(7)" json:"thumbnail_color"`
+	TrailerURL        string         `json:"trailer_url"`
+	VideoURL          string         `json:"video_url"`
+	Published         bool           `gorm:"default:false;index" json:"published"`
+	AvailableFrom     *time.Time     `gorm:"index" json:"available_from,omitempty"`
+	AvailableUntil    *time.Time     `gorm:"index" json:"available_until,omitempty"`
+	AllowedCountries  CountryCodes   `gorm:"type:text;not null;default:''" json:"allowed_countries"`
+	BlockedCountries  CountryCodes   `gorm:"type:text;not null;default:''" json:"blocked_countries"`
+	RatingAverage     float64        `gorm:"not null;default:0" json:"rating_average"`
+	RatingCount       int            `gorm:"not null;default:0" json:"rating_count"`
+	Markers           ContentMarkers `gorm:"embedded" json:"markers"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 func (Content) TableName() string {
 	return "contents"
 }
+func (c *Content) IsWithinAvailabilityWindow(at time.Time) bool {
+	if c.AvailableFrom != nil && at.Before(*c.AvailableFrom) {
+		return false
+	}
+	if c.AvailableUntil != nil && !at.Before(*c.AvailableUntil) {
+		return false
+	}
+	return true
+}
+func (c *Content) IsAvailableAt(at time.Time) bool {
+	return !c.IsDeleted() && c.Published && c.IsWithinAvailabilityWindow(at)
+}
+func (c *Content) IsDeleted() bool {
+	return c.DeletedAt.Valid
+}
+func (c *Content) IsAvailableInCountry(country string) bool {
+	if len(c.AllowedCountries) > 0 && !c.AllowedCountries.Contains(country) {
+		return false
+	}
+	return !c.BlockedCountries.Contains(country)
+}
+
+type ContentEventType string
+
+const (
+	ContentEventPublished ContentEventType = "content.published"
+	ContentEventExpired   ContentEventType = "content.expired"
+)
+
+type ContentEvent struct {
+	Type       ContentEventType `json:"type"`
+	ContentID  uuid.UUID        `json:"content_id"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
 
 type Plan struct {
-	ID                uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Name              string      `gorm:"not null;uniqueIndex" json:"name"`
-	Price             int64       `gorm:"not null" json:"price"`
-	ValidityDays      int         `gorm:"not null" json:"validity_days"`
-	AccessLevel       AccessLevel `gorm:"type:varchar(20);not null" json:"access_level"`
-	MaxDevicesAllowed int         `gorm:"not null;default:1" json:"max_devices_allowed"`
-	Resolution        string      `json:"resolution"`
-	Description       string      `json:"description"`
-	IsActive          bool        `gorm:"default:true;index" json:"is_active"`
-	CreatedAt         time.Time   `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name              string         `gorm:"not null;uniqueIndex" json:"name"`
+	Price             int64          `gorm:"not null" json:"price"`
+	ValidityDays      int            `gorm:"not null" json:"validity_days"`
+	AccessLevel       AccessLevel    `gorm:"type:varchar(20);not null" json:"access_level"`
+	MaxDevicesAllowed int            `gorm:"not null;default:1" json:"max_devices_allowed"`
+	Resolution        Resolution     `gorm:"type:varchar(10)" json:"resolution"`
+	Description       string         `json:"description"`
+	Locale            string         `gorm:"-" json:"locale,omitempty"`
+	IsActive          bool           `gorm:"default:true;index" json:"is_active"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 func (Plan) TableName() string { return "plans" }
 
+type PlanTranslation struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlanID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_plan_translation" json:"plan_id"`
+	Locale      string    `gorm:"type:varchar(35);not null;uniqueIndex:idx_plan_translation" json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (PlanTranslation) TableName() string { return "plan_translations" }
+
+// MaxResolution caps the plan's streams, plans without a resolution get the
+// lowest tier. An unknown resolution is an error rather than a silent downgrade.
+func (p *Plan) MaxResolution() (Resolution, error) {
+	if p.Resolution == "" {
+		return Resolution480p, nil
+	}
+	resolution, err := ParseResolution(string(p.Resolution))
+	if err != nil {
+		return "", fmt.Errorf("plan %s has resolution %q: %w", p.ID, p.Resolution, err)
+	}
+	return resolution, nil
+}
+
 type Subscription struct {
 	ID        uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID    uuid.UUID          `gorm:"type:uuid;not null;index" json:"user_id"`
@@ -99,17 +262,22 @@ func (Subscription) TableName() string  { return "subscriptions" }
 func (s *Subscription) IsExpired() bool { return time.Now().After(s.EndDate) }
 
 type WatchHistory struct {
-	ID             uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	UserID         uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
-	ContentID      uuid.UUID   `gorm:"type:uuid;not null;index" json:"content_id"`
-	User           *User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Content        *Content    `gorm:"foreignKey:ContentID" json:"content,omitempty"`
-	WatchedSeconds int         `gorm:"not null;default:0" json:"watched_seconds"`
-	TotalSeconds   int         `gorm:"not null" json:"total_seconds"`
-	Status         WatchStatus `gorm:"type:varchar(20);not null;default:'started'" json:"status"`
-	LastWatchedAt  time.Time   `gorm:"not null" json:"last_watched_at"`
-	CreatedAt      time.Time   `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                 uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID             uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+	ContentID          uuid.UUID   `gorm:"type:uuid;not null;index" json:"content_id"`
+	User               *User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Content            *Content    `gorm:"foreignKey:ContentID" json:"content,omitempty"`
+	WatchedSeconds     int         `gorm:"not null;default:0" json:"watched_seconds"`
+	TotalSeconds       int         `gorm:"not null" json:"total_seconds"`
+	Status             WatchStatus `gorm:"type:varchar(20);not null;default:'started'" json:"status"`
+	RewatchCount       int         `gorm:"not null;default:0" json:"rewatch_count"`
+	CompletedAt        *time.Time  `json:"completed_at,omitempty"`
+	DeviceID           string      `gorm:"type:varchar(100)" json:"device_id,omitempty"`
+	LastWatchedAt      time.Time   `gorm:"not null" json:"last_watched_at"`
+	Country            string      `gorm:"type:varchar(2);index" json:"country,omitempty"`
+	ContentUnavailable bool        `gorm:"-" json:"content_unavailable,omitempty"`
+	CreatedAt          time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 func (WatchHistory) TableName() string { return "watch_histories" }
@@ -119,6 +287,255 @@ func (w *WatchHistory) ProgressPercentage() float64 {
 	}
 	return (float64(w.WatchedSeconds) / float64(w.TotalSeconds)) * 100
 }
-func (w *WatchHistory) IsCompleted() bool {
-	return w.Status == WatchStatusCompleted || w.ProgressPercentage() >= 90.0
+
+// ReachedCompletionPoint applies the completion policy to the current position
+func (w *WatchHistory) ReachedCompletionPoint(policy CompletionPolicy, content *Content) bool {
+	return policy.IsComplete(w.WatchedSeconds, w.TotalSeconds, content)
+}
+
+type Person struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string     `gorm:"not null;index" json:"name"`
+	Bio       string     `json:"bio"`
+	PhotoURL  string     `json:"photo_url"`
+	BirthDate *time.Time `json:"birth_date,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Person) TableName() string { return "people" }
+
+type ContentCredit struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_content_person_role" json:"content_id"`
+	PersonID      uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_content_person_role" json:"person_id"`
+	Content       *Content   `gorm:"foreignKey:ContentID" json:"content,omitempty"`
+	Person        *Person    `gorm:"foreignKey:PersonID" json:"person,omitempty"`
+	Role          PersonRole `gorm:"type:varchar(20);not null;uniqueIndex:idx_content_person_role" json:"role"`
+	CharacterName string     `json:"character_name"`
+	BillingOrder  int        `gorm:"not null;default:0" json:"billing_order"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
+
+func (ContentCredit) TableName() string { return "content_credits" }
+
+type VideoRendition struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_rendition_variant" json:"content_id"`
+	Resolution  Resolution `gorm:"type:varchar(10);not null;uniqueIndex:idx_rendition_variant" json:"resolution"`
+	BitrateKbps int        `gorm:"not null" json:"bitrate_kbps"`
+	Codec       string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_rendition_variant" json:"codec"`
+	URL         string     `gorm:"not null" json:"url"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (VideoRendition) TableName() string { return "video_renditions" }
+
+type SubtitleFormat string
+
+const (
+	SubtitleFormatWebVTT SubtitleFormat = "webvtt"
+	SubtitleFormatSRT    SubtitleFormat = "srt"
+)
+
+type SubtitleTrack struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_subtitle_variant" json:"content_id"`
+	Language  string         `gorm:"type:varchar(35);not null;uniqueIndex:idx_subtitle_variant" json:"language"`
+	Label     string         `json:"label"`
+	Format    SubtitleFormat `gorm:"type:varchar(10);not null" json:"format"`
+	URL       string         `json:"url"`
+	Body      string         `gorm:"type:text" json:"-"`
+	IsForced  bool           `gorm:"not null;default:false;uniqueIndex:idx_subtitle_variant" json:"is_forced"`
+	IsSDH     bool           `gorm:"not null;default:false;uniqueIndex:idx_subtitle_variant" json:"is_sdh"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (SubtitleTrack) TableName() string { return "subtitle_tracks" }
+
+type ContentTranslation struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_content_translation" json:"content_id"`
+	Locale      string    `gorm:"type:varchar(35);not null;uniqueIndex:idx_content_translation" json:"locale"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ContentTranslation) TableName() string { return "content_translations" }
+
+type AudioTrack struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_audio_variant" json:"content_id"`
+	Language    string    `gorm:"type:varchar(35);not null;uniqueIndex:idx_audio_variant" json:"language"`
+	Label       string    `json:"label"`
+	Codec       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_audio_variant" json:"codec"`
+	Channels    int       `gorm:"not null;default:2" json:"channels"`
+	IsDefault   bool      `gorm:"not null;default:false" json:"is_default"`
+	IsDescribed bool      `gorm:"not null;default:false;uniqueIndex:idx_audio_variant" json:"is_described"`
+	URL         string    `gorm:"not null" json:"url"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (AudioTrack) TableName() string { return "audio_tracks" }
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+type Review struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_review_user_content" json:"user_id"`
+	ContentID      uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_review_user_content;index" json:"content_id"`
+	User           *User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Rating         int          `gorm:"not null" json:"rating"`
+	Body           string       `gorm:"type:text" json:"body"`
+	Status         ReviewStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	ModerationNote string       `json:"moderation_note,omitempty"`
+	ModeratedBy    *uuid.UUID   `gorm:"type:uuid" json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time   `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Review) TableName() string { return "reviews" }
+
+type WatchlistItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_watchlist_user_content;index:idx_watchlist_user_position" json:"user_id"`
+	ContentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_watchlist_user_content" json:"content_id"`
+	Content   *Content  `gorm:"foreignKey:ContentID" json:"content,omitempty"`
+	Position  int       `gorm:"not null;index:idx_watchlist_user_position" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (WatchlistItem) TableName() string { return "watchlist_items" }
+
+type ContentSimilarity struct {
+	ContentID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"content_id"`
+	SimilarContentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"similar_content_id"`
+	SimilarContent   *Content  `gorm:"foreignKey:SimilarContentID" json:"similar_content,omitempty"`
+	Score            float64   `gorm:"not null;index" json:"score"`
+	CoViewers        int       `gorm:"not null" json:"co_viewers"`
+	ComputedAt       time.Time `gorm:"not null" json:"computed_at"`
+}
+
+func (ContentSimilarity) TableName() string { return "content_similarities" }
+
+type TrendingWindow string
+
+const (
+	TrendingWindowDay  TrendingWindow = "day"
+	TrendingWindowWeek TrendingWindow = "week"
+)
+
+func (w TrendingWindow) Duration() (time.Duration, bool) {
+	switch w {
+	case TrendingWindowDay:
+		return 24 * time.Hour, true
+	case TrendingWindowWeek:
+		return 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+type ContentActivity struct {
+	ContentID     uuid.UUID `json:"content_id"`
+	Content       *Content  `gorm:"-" json:"content"`
+	UniqueViewers int64     `json:"unique_viewers"`
+	Completions   int64     `json:"completions"`
+	WatchSeconds  int64     `json:"watch_seconds"`
+}
+
+type Collection struct {
+	ID           uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Title        string            `gorm:"not null" json:"title"`
+	Description  string            `json:"description"`
+	AccessLevel  AccessLevel       `gorm:"type:varchar(20);not null;default:'free'" json:"access_level"`
+	Position     int               `gorm:"not null;default:0;index" json:"position"`
+	VisibleFrom  *time.Time        `gorm:"index" json:"visible_from,omitempty"`
+	VisibleUntil *time.Time        `gorm:"index" json:"visible_until,omitempty"`
+	Items        []*CollectionItem `gorm:"foreignKey:CollectionID" json:"items,omitempty"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Collection) TableName() string { return "collections" }
+func (c *Collection) IsVisibleAt(at time.Time) bool {
+	if c.VisibleFrom != nil && at.Before(*c.VisibleFrom) {
+		return false
+	}
+	return c.VisibleUntil == nil || at.Before(*c.VisibleUntil)
+}
+
+type CollectionItem struct {
+	CollectionID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	ContentID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"content_id"`
+	Content      *Content  `gorm:"foreignKey:ContentID" json:"content,omitempty"`
+	Position     int       `gorm:"not null" json:"position"`
+}
+
+func (CollectionItem) TableName() string { return "collection_items" }
+
+type RevisionStatus string
+
+const (
+	RevisionStatusPublished RevisionStatus = "published"
+	RevisionStatusDraft     RevisionStatus = "draft"
+)
+
+type ContentRevision struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ContentID    uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_revision_content_number" json:"content_id"`
+	Number       int             `gorm:"not null;uniqueIndex:idx_revision_content_number" json:"number"`
+	Status       RevisionStatus  `gorm:"type:varchar(20);not null;index" json:"status"`
+	AuthorID     uuid.UUID       `gorm:"type:uuid;not null" json:"author_id"`
+	Author       *User           `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Snapshot     ContentSnapshot `gorm:"type:jsonb;not null" json:"snapshot"`
+	Changes      RevisionChanges `gorm:"type:jsonb;not null;default:'{}'" json:"changes"`
+	RestoredFrom *int            `json:"restored_from,omitempty"`
+	CreatedAt    time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ContentRevision) TableName() string { return "content_revisions" }
+
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+type ImportJob struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AuthorID   uuid.UUID       `gorm:"type:uuid;not null;index" json:"author_id"`
+	Format     CatalogFormat   `gorm:"type:varchar(10);not null" json:"format"`
+	DryRun     bool            `gorm:"not null" json:"dry_run"`
+	Status     ImportStatus    `gorm:"type:varchar(20);not null;index" json:"status"`
+	TotalRows  int             `gorm:"not null" json:"total_rows"`
+	Processed  int             `gorm:"not null;default:0" json:"processed"`
+	Created    int             `gorm:"not null;default:0" json:"created"`
+	Updated    int             `gorm:"not null;default:0" json:"updated"`
+	Unchanged  int             `gorm:"not null;default:0" json:"unchanged"`
+	Failed     int             `gorm:"not null;default:0" json:"failed"`
+	Errors     ImportRowErrors `gorm:"type:jsonb;not null;default:'[]'" json:"errors"`
+	Message    string          `json:"message,omitempty"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ImportJob) TableName() string { return "import_jobs" }
@@ -3,30 +3,76 @@ package domain
 import "errors"
 
 var (
-	ErrInvalidCredentials        = errors.New("invalid email or password")
-	ErrUnauthorized              = errors.New("unauthorized access")
-	ErrForbidden                 = errors.New("forbidden: insufficient permissions")
-	ErrTokenExpired              = errors.New("token has expired")
-	ErrTokenInvalid              = errors.New("invalid token")
-	ErrTokenMissing              = errors.New("token missing")
-	ErrUserExists                = errors.New("user with this email already exists")
-	ErrUserNotFound              = errors.New("user not found")
-	ErrInvalidInput              = errors.New("invalid input data")
-	ErrValidationFailed          = errors.New("validation failed")
-	ErrContentNotFound           = errors.New("content not found")
-	ErrContentNotAccessible      = errors.New("you don't have access to this content")
-	ErrContentNotPublished       = errors.New("content is not published")
-	ErrPlanNotFound              = errors.New("plan not found")
-	ErrPlanNotAvailable          = errors.New("plan is not available")
-	ErrInactivePlan              = errors.New("plan is inactive")
-	ErrSubscriptionNotFound      = errors.New("subscription not found")
-	ErrSubscriptionExpired       = errors.New("subscription has expired")
-	ErrSubscriptionInactive      = errors.New("subscription is inactive")
-	ErrActiveSubscriptionExists  = errors.New("user already has an active subscription")
-	ErrSubscriptionLimitExceeded = errors.New("maximum concurrent device limit exceeded")
-	ErrWatchHistoryNotFound      = errors.New("watch history not found")
-	ErrInvalidProgress           = errors.New("invalid progress data")
-	ErrNotFound                  = errors.New("resource not found")
-	ErrInternalServer            = errors.New("internal server error")
-	ErrDatabaseError             = errors.New("database operation failed")
+	ErrInvalidCredentials          = errors.New("invalid email or password")
+	ErrUnauthorized                = errors.New("unauthorized access")
+	ErrForbidden                   = errors.New("forbidden: insufficient permissions")
+	ErrTokenExpired                = errors.New("token has expired")
+	ErrTokenInvalid                = errors.New("invalid token")
+	ErrTokenMissing                = errors.New("token missing")
+	ErrUserExists                  = errors.New("user with this email already exists")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrInvalidInput                = errors.New("invalid input data")
+	ErrValidationFailed            = errors.New("validation failed")
+	ErrContentNotFound             = errors.New("content not found")
+	ErrContentNotAccessible        = errors.New("you don't have access to this content")
+	ErrContentNotPublished         = errors.New("content is not published")
+	ErrContentNotAvailable         = errors.New("content is not available at this time")
+	ErrInvalidAvailability         = errors.New("available_until must be after available_from")
+	ErrContentNotAvailableInRegion = errors.New("content is not available in your region")
+	ErrInvalidCountryCode          = errors.New("invalid ISO 3166 country code")
+	ErrPlaybackUnavailable         = errors.New("no playable media for this content")
+	ErrRenditionNotFound           = errors.New("rendition not found")
+	ErrInvalidResolution           = errors.New("resolution must be one of 480p, 720p, 1080p, 4K")
+	ErrInvalidManifestFormat       = errors.New("unsupported manifest format")
+	ErrRenditionExists             = errors.New("rendition with this resolution and codec already exists")
+	ErrTrackNotFound               = errors.New("track not found")
+	ErrTrackExists                 = errors.New("track with this language and flags already exists")
+	ErrDefaultTrackExists          = errors.New("content already has a default audio track")
+	ErrInvalidLanguageTag          = errors.New("invalid BCP 47 language tag")
+	ErrInvalidSubtitle             = errors.New("subtitle file is not valid SRT or WebVTT")
+	ErrSubtitleSource              = errors.New("provide either a subtitle url or a subtitle file")
+	ErrFileTooLarge                = errors.New("file exceeds the maximum upload size")
+	ErrImageTooLarge               = errors.New("image exceeds the maximum pixel count")
+	ErrUnsupportedMediaType        = errors.New("unsupported file type")
+	ErrReviewNotFound              = errors.New("review not found")
+	ErrRatingRequiresWatch         = errors.New("you can only rate content you have watched")
+	ErrInvalidReviewStatus         = errors.New("status must be approved or rejected")
+	ErrWatchlistItemNotFound       = errors.New("content is not in your watchlist")
+	ErrWatchlistItemExists         = errors.New("content is already in your watchlist")
+	ErrInvalidWatchlistOrder       = errors.New("order must list every watchlist item exactly once")
+	ErrInvalidTrendingWindow       = errors.New("window must be day or week")
+	ErrCollectionNotFound          = errors.New("collection not found")
+	ErrInvalidVisibility           = errors.New("visible_until must be after visible_from")
+	ErrInvalidCursor               = errors.New("invalid cursor")
+	ErrInvalidSort                 = errors.New("unsupported sort field")
+	ErrInvalidFilter               = errors.New("invalid content filter")
+	ErrRevisionNotFound            = errors.New("revision not found")
+	ErrDraftNotFound               = errors.New("content has no draft")
+	ErrInvalidCatalogFormat        = errors.New("format must be csv or jsonl")
+	ErrInvalidImportFile           = errors.New("import file is not valid for its format")
+	ErrImportJobNotFound           = errors.New("import job not found")
+	ErrTranslationNotFound         = errors.New("translation not found")
+	ErrDefaultLocaleTranslation    = errors.New("the default locale is edited on the record itself")
+	ErrInvalidMarkers              = errors.New("invalid intro, credits or chapter markers")
+	ErrInvalidCompletionPolicy     = errors.New("invalid completion policy")
+	ErrProgressBeyondDuration      = errors.New("progress is beyond the content duration")
+	ErrClientTimeInFuture          = errors.New("client time is in the future")
+	ErrInvalidStatsPeriod          = errors.New("period must be week, month, year or all")
+	ErrInvalidReviewYear           = errors.New("year must be between 1970 and the current year")
+	ErrPlanNotFound                = errors.New("plan not found")
+	ErrPlanNotAvailable            = errors.New("plan is not available")
+	ErrInactivePlan                = errors.New("plan is inactive")
+	ErrSubscriptionNotFound        = errors.New("subscription not found")
+	ErrSubscriptionExpired         = errors.New("subscription has expired")
+	ErrSubscriptionInactive        = errors.New("subscription is inactive")
+	ErrActiveSubscriptionExists    = errors.New("user already has an active subscription")
+	ErrSubscriptionLimitExceeded   = errors.New("maximum concurrent device limit exceeded")
+	ErrWatchHistoryNotFound        = errors.New("watch history not found")
+	ErrInvalidProgress             = errors.New("invalid progress data")
+	ErrPersonNotFound              = errors.New("person not found")
+	ErrCreditNotFound              = errors.New("credit not found")
+	ErrCreditExists                = errors.New("person already credited in this role")
+	ErrNotFound                    = errors.New("resource not found")
+	ErrInternalServer              = errors.New("internal server error")
+	ErrDatabaseError               = errors.New("database operation failed")
 )
@@ -0,0 +1,8 @@
+package domain
+
+import "strings"
+
+// NormalizeGenre lowercases and collapses whitespace so "Sci  Fi" and "sci fi" share a row
+func NormalizeGenre(genre string) string {
+	return strings.ToLower(strings.Join(strings.Fields(genre), " "))
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type ImageVariant struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
+
+// ImageVariants is persisted as a JSON array ordered by ascending width
+type ImageVariants []ImageVariant
+
+func (v ImageVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (v *ImageVariants) Scan(value interface{}) error {
+	switch raw := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(raw), v)
+	case []byte:
+		return json.Unmarshal(raw, v)
+	default:
+		return fmt.Errorf("cannot scan %T into ImageVariants", value)
+	}
+}
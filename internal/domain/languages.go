@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Simplified BCP 47: language, optional script, optional region/variants
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+func NormalizeLanguageTag(tag string) (string, error) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if !languageTagPattern.MatchString(tag) {
+		return "", ErrInvalidLanguageTag
+	}
+	parts := strings.Split(tag, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch {
+		case len(parts[i]) == 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		case len(parts[i]) == 2:
+			parts[i] = strings.ToUpper(parts[i])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header ordered by
+// quality, skipping wildcards, malformed tags and anything the client refused with q=0
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag, err := NormalizeLanguageTag(fields[0])
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q >= 0 && q <= 1 {
+				quality = q
+			} else {
+				quality = 0
+			}
+		}
+		if quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// LocaleChain expands preferred tags into the order translations are tried in,
+// each tag followed by its less specific parents (pt-BR, pt). The chain ends
+// at the default locale, whose text lives on the record itself.
+func LocaleChain(preferred []string, defaultLocale string) []string {
+	seen := make(map[string]bool)
+	var chain []string
+	for _, tag := range preferred {
+		for tag != "" {
+			if tag == defaultLocale {
+				return append(chain, defaultLocale)
+			}
+			if !seen[tag] {
+				seen[tag] = true
+				chain = append(chain, tag)
+			}
+			if i := strings.LastIndex(tag, "-"); i > 0 {
+				tag = tag[:i]
+			} else {
+				tag = ""
+			}
+		}
+	}
+	return append(chain, defaultLocale)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+const MaxChapters = 100
+
+type Chapter struct {
+	Title        string `json:"title"`
+	StartSeconds int    `json:"start_seconds"`
+}
+
+// Chapters is persisted as a JSON array ordered by start time
+type Chapters []Chapter
+
+func (c Chapters) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *Chapters) Scan(value interface{}) error {
+	switch raw := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(raw), c)
+	case []byte:
+		return json.Unmarshal(raw, c)
+	default:
+		return fmt.Errorf("cannot scan %T into Chapters", value)
+	}
+}
+
+// ContentMarkers let players offer "Skip Intro" and "Skip Credits" and jump
+// between chapters. All positions are seconds from the start of the video.
+type ContentMarkers struct {
+	IntroStartSeconds   *int     `json:"intro_start_seconds,omitempty"`
+	IntroEndSeconds     *int     `json:"intro_end_seconds,omitempty"`
+	CreditsStartSeconds *int     `json:"credits_start_seconds,omitempty"`
+	Chapters            Chapters `gorm:"type:jsonb;not null;default:'[]'" json:"chapters"`
+}
+
+// Validate checks the markers fit inside a runtime of durationSeconds: the
+// intro needs both ends, and chapters must have titles and strictly increasing starts
+func (m ContentMarkers) Validate(durationSeconds int) error {
+	if (m.IntroStartSeconds == nil) != (m.IntroEndSeconds == nil) {
+		return ErrInvalidMarkers
+	}
+	if m.IntroStartSeconds != nil {
+		if *m.IntroStartSeconds < 0 || *m.IntroEndSeconds <= *m.IntroStartSeconds || *m.IntroEndSeconds > durationSeconds {
+			return ErrInvalidMarkers
+		}
+	}
+	if credits := m.CreditsStartSeconds; credits != nil {
+		if *credits <= 0 || *credits >= durationSeconds {
+			return ErrInvalidMarkers
+		}
+		if m.IntroEndSeconds != nil && *credits < *m.IntroEndSeconds {
+			return ErrInvalidMarkers
+		}
+	}
+	if len(m.Chapters) > MaxChapters {
+		return ErrInvalidMarkers
+	}
+	for i, chapter := range m.Chapters {
+		if chapter.Title == "" || chapter.StartSeconds < 0 || chapter.StartSeconds >= durationSeconds {
+			return ErrInvalidMarkers
+		}
+		if i > 0 && chapter.StartSeconds <= m.Chapters[i-1].StartSeconds {
+			return ErrInvalidMarkers
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ContentSnapshot holds the editable fields of a content revision, JSON names
+// match the admin content input. Markers are edited on their own, a snapshot
+// without them, such as the admin input or a revision saved before they were
+// tracked, leaves the live markers as they are.
+type ContentSnapshot struct {
+	Title            string          `json:"title"`
+	Description      string          `json:"description"`
+	AccessLevel      AccessLevel     `json:"access_level"`
+	ContentType      ContentType     `json:"content_type"`
+	Genre            string          `json:"genre"`
+	DurationSeconds  int             `json:"duration_seconds"`
+	ThumbnailURL     string          `json:"thumbnail_url"`
+	TrailerURL       string          `json:"trailer_url"`
+	VideoURL         string          `json:"video_url"`
+	Published        bool            `json:"published"`
+	AvailableFrom    *time.Time      `json:"available_from"`
+	AvailableUntil   *time.Time      `json:"available_until"`
+	AllowedCountries CountryCodes    `json:"allowed_countries"`
+	BlockedCountries CountryCodes    `json:"blocked_countries"`
+	Markers          *ContentMarkers `json:"markers,omitempty"`
+}
+
+func (s ContentSnapshot) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *ContentSnapshot) Scan(value interface{}) error {
+	switch raw := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(raw), s)
+	case []byte:
+		return json.Unmarshal(raw, s)
+	default:
+		return fmt.Errorf("cannot scan %T into ContentSnapshot", value)
+	}
+}
+
+// Normalize gives equal snapshots equal representations, times are compared
+// in UTC at database precision, empty country and chapter lists are nil and a
+// missing content type is a movie
+func (s ContentSnapshot) Normalize() ContentSnapshot {
+	normalizeTime := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		normalized := t.UTC().Truncate(time.Microsecond)
+		return &normalized
+	}
+	if s.ContentType == "" {
+		s.ContentType = ContentTypeMovie
+	}
+	s.AvailableFrom = normalizeTime(s.AvailableFrom)
+	s.AvailableUntil = normalizeTime(s.AvailableUntil)
+	if len(s.AllowedCountries) == 0 {
+		s.AllowedCountries = nil
+	}
+	if len(s.BlockedCountries) == 0 {
+		s.BlockedCountries = nil
+	}
+	if s.Markers != nil && s.Markers.Chapters != nil && len(s.Markers.Chapters) == 0 {
+		markers := *s.Markers
+		markers.Chapters = nil
+		s.Markers = &markers
+	}
+	return s
+}
+
+// Diff lists the fields that differ in next, keyed by their JSON name
+func (s ContentSnapshot) Diff(next ContentSnapshot) RevisionChanges {
+	if next.Markers == nil {
+		next.Markers = s.Markers
+	}
+	changes := RevisionChanges{}
+	before := reflect.ValueOf(s.Normalize())
+	after := reflect.ValueOf(next.Normalize())
+	fields := before.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		from, to := before.Field(i).Interface(), after.Field(i).Interface()
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		name, _, _ := strings.Cut(fields.Field(i).Tag.Get("json"), ",")
+		changes[name] = FieldChange{From: from, To: to}
+	}
+	return changes
+}
+
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// RevisionChanges is persisted as a JSON object of field name to change
+type RevisionChanges map[string]FieldChange
+
+func (c RevisionChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *RevisionChanges) Scan(value interface{}) error {
+	switch raw := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(raw), c)
+	case []byte:
+		return json.Unmarshal(raw, c)
+	default:
+		return fmt.Errorf("cannot scan %T into RevisionChanges", value)
+	}
+}
+
+func (c *Content) Snapshot() ContentSnapshot {
+	markers := c.Markers
+	return ContentSnapshot{
+		Title:            c.Title,
+		Description:      c.Description,
+		AccessLevel:      c.AccessLevel,
+		ContentType:      c.ContentType,
+		Genre:            c.Genre,
+		DurationSeconds:  c.DurationSeconds,
+		ThumbnailURL:     c.ThumbnailURL,
+		TrailerURL:       c.TrailerURL,
+		VideoURL:         c.VideoURL,
+		Published:        c.Published,
+		AvailableFrom:    c.AvailableFrom,
+		AvailableUntil:   c.AvailableUntil,
+		AllowedCountries: c.AllowedCountries,
+		BlockedCountries: c.BlockedCountries,
+		Markers:          &markers,
+	}.Normalize()
+}
+
+// ApplySnapshot overwrites the editable fields, generated artwork is dropped
+// when the thumbnail changes
+func (c *Content) ApplySnapshot(s ContentSnapshot) {
+	if c.ThumbnailURL != s.ThumbnailURL {
+		c.ThumbnailVariants = nil
+		c.ThumbnailBlurHash = ""
+		c.ThumbnailColor = ""
+	}
+	c.Title = s.Title
+	c.Description = s.Description
+	c.AccessLevel = s.AccessLevel
+	c.ContentType = s.ContentType
+	c.Genre = s.Genre
+	c.DurationSeconds = s.DurationSeconds
+	c.ThumbnailURL = s.ThumbnailURL
+	c.TrailerURL = s.TrailerURL
+	c.VideoURL = s.VideoURL
+	c.Published = s.Published
+	c.AvailableFrom = s.AvailableFrom
+	c.AvailableUntil = s.AvailableUntil
+	c.AllowedCountries = s.AllowedCountries
+	c.BlockedCountries = s.BlockedCountries
+	if s.Markers != nil {
+		c.Markers = *s.Markers
+	}
+}
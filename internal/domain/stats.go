@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatsPeriod string
+
+const (
+	StatsPeriodWeek  StatsPeriod = "week"
+	StatsPeriodMonth StatsPeriod = "month"
+	StatsPeriodYear  StatsPeriod = "year"
+	StatsPeriodAll   StatsPeriod = "all"
+)
+
+// Since returns where the rolling period ending at now starts, the zero time
+// for all time
+func (p StatsPeriod) Since(now time.Time) (time.Time, bool) {
+	switch p {
+	case StatsPeriodWeek:
+		return now.AddDate(0, 0, -7), true
+	case StatsPeriodMonth:
+		return now.AddDate(0, -1, 0), true
+	case StatsPeriodYear:
+		return now.AddDate(-1, 0, 0), true
+	case StatsPeriodAll:
+		return time.Time{}, true
+	}
+	return time.Time{}, false
+}
+
+type WatchTotals struct {
+	WatchSeconds    int64 `json:"watch_seconds"`
+	TitlesWatched   int64 `json:"titles_watched"`
+	TitlesCompleted int64 `json:"titles_completed"`
+}
+
+type GenreActivity struct {
+	Genre        string `json:"genre"`
+	Titles       int64  `json:"titles"`
+	WatchSeconds int64  `json:"watch_seconds"`
+}
+
+type TitleActivity struct {
+	ContentID    uuid.UUID `json:"content_id"`
+	Content      *Content  `gorm:"-" json:"content"`
+	WatchSeconds int64     `json:"watch_seconds"`
+	RewatchCount int       `json:"rewatch_count"`
+	Completed    bool      `json:"completed"`
+}
+
+type MonthActivity struct {
+	Month        int   `json:"month"`
+	Titles       int64 `json:"titles"`
+	WatchSeconds int64 `json:"watch_seconds"`
+}
+
+type Streaks struct {
+	CurrentDays int `json:"current_days"`
+	LongestDays int `json:"longest_days"`
+	ActiveDays  int `json:"active_days"`
+}
+
+// ComputeStreaks counts runs of consecutive UTC days in the sorted activity
+// days. The current streak survives until a full day passes without viewing.
+func ComputeStreaks(days []time.Time, today time.Time) Streaks {
+	streaks := Streaks{ActiveDays: len(days)}
+	run := 0
+	var previous time.Time
+	for i, day := range days {
+		if i > 0 && day.Sub(previous) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		streaks.LongestDays = max(streaks.LongestDays, run)
+		previous = day
+	}
+	if len(days) > 0 {
+		today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+		if gap := today.Sub(previous); gap >= 0 && gap <= 24*time.Hour {
+			streaks.CurrentDays = run
+		}
+	}
+	return streaks
+}
+
+type ViewerStats struct {
+	Period StatsPeriod `json:"period"`
+	From   *time.Time  `json:"from,omitempty"`
+	To     time.Time   `json:"to"`
+	WatchTotals
+	FavoriteGenres []*GenreActivity `json:"favorite_genres"`
+	Streaks        Streaks          `json:"streaks"`
+}
+
+type YearInReview struct {
+	Year int `json:"year"`
+	WatchTotals
+	FavoriteGenres []*GenreActivity `json:"favorite_genres"`
+	TopTitles      []*TitleActivity `json:"top_titles"`
+	Months         []*MonthActivity `json:"months"`
+	Streaks        Streaks          `json:"streaks"`
+}
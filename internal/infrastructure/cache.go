@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -72,6 +73,11 @@ func (c *Cache) CheckRateLimit(ctx context.Context, identifier string, maxReques
 	return count <= maxRequests, nil
 }
 
+// IsCacheMiss reports whether a Get failed only because the key is not set
+func IsCacheMiss(err error) bool {
+	return errors.Is(err, redis.Nil)
+}
+
 func (c *Cache) Close() error {
 	return c.client.Close()
 }
@@ -41,10 +41,39 @@ func AutoMigrate(db *gorm.DB) error {
 		&domain.Plan{},
 		&domain.Subscription{},
 		&domain.WatchHistory{},
+		&domain.Person{},
+		&domain.ContentCredit{},
+		&domain.VideoRendition{},
+		&domain.SubtitleTrack{},
+		&domain.AudioTrack{},
+		&domain.Review{},
+		&domain.WatchlistItem{},
+		&domain.ContentSimilarity{},
+		&domain.Collection{},
+		&domain.CollectionItem{},
+		&domain.ContentRevision{},
+		&domain.ImportJob{}, &domain.ContentTranslation{}, &domain.PlanTranslation{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
+	if err := normalizePlanResolutions(db); err != nil {
+		return fmt.Errorf("failed to migrate plan resolutions: %w", err)
+	}
 	log.Println("Database migration completed")
 	return nil
 }
+
+// normalizePlanResolutions rewrites resolutions saved in another case, such as
+// "4k", to the canonical value the domain compares against
+func normalizePlanResolutions(db *gorm.DB) error {
+	for _, resolution := range []domain.Resolution{domain.Resolution480p, domain.Resolution720p, domain.Resolution1080p, domain.Resolution4K} {
+		err := db.Unscoped().Model(&domain.Plan{}).
+			Where("LOWER(resolution) = LOWER(?) AND resolution <> ?", resolution, resolution).
+			Update("resolution", resolution).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+)
+
+type ContentEventHandler func(ctx context.Context, event domain.ContentEvent)
+
+// EventBusInterface defines the interface for in-process content event fan-out
+type EventBusInterface interface {
+	Publish(ctx context.Context, event domain.ContentEvent)
+	Subscribe(eventType domain.ContentEventType, handler ContentEventHandler)
+}
+
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[domain.ContentEventType][]ContentEventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[domain.ContentEventType][]ContentEventHandler)}
+}
+
+func (b *EventBus) Publish(ctx context.Context, event domain.ContentEvent) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
+
+func (b *EventBus) Subscribe(eventType domain.ContentEventType, handler ContentEventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
@@ -0,0 +1,150 @@
+package infrastructure
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+)
+
+// GeoResolver resolves a viewer's ISO 3166 country, preferring a CDN header
+// (e.g. CF-IPCountry) set by a trusted proxy and falling back to an offline IP
+// range database
+type GeoResolver struct {
+	header  string
+	proxies []netip.Prefix
+	ranges  []ipRange
+}
+
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// NewGeoResolver loads the optional database, a CSV of start_ip,end_ip,country
+// rows as distributed by the DB-IP "IP to Country Lite" dataset. The country
+// header is only honoured from the trusted proxies, given as IPs or CIDRs.
+func NewGeoResolver(header, databasePath string, trustedProxies []string) (*GeoResolver, error) {
+	proxies, err := parseProxies(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	resolver := &GeoResolver{header: header, proxies: proxies}
+	if databasePath == "" {
+		return resolver, nil
+	}
+	file, err := os.Open(databasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open geoip database: %w", err)
+	}
+	defer file.Close()
+	ranges, err := parseIPRanges(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geoip database: %w", err)
+	}
+	resolver.ranges = ranges
+	return resolver, nil
+}
+
+func parseProxies(values []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+func parseIPRanges(r io.Reader) ([]ipRange, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected start_ip,end_ip,country", line)
+		}
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ranges = append(ranges, ipRange{
+			start:   start.Unmap(),
+			end:     end.Unmap(),
+			country: strings.ToUpper(strings.TrimSpace(record[2])),
+		})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start.Less(ranges[j].start) })
+	return ranges, nil
+}
+
+// Country returns the caller's country code or "" when it cannot be determined.
+// remoteIP is the address of the direct peer, clientIP the resolved viewer.
+func (g *GeoResolver) Country(req *http.Request, remoteIP, clientIP string) string {
+	if g.header != "" && g.isTrustedProxy(remoteIP) {
+		if code := strings.ToUpper(strings.TrimSpace(req.Header.Get(g.header))); domain.IsValidCountryCode(code) {
+			return code
+		}
+	}
+	return g.Lookup(clientIP)
+}
+
+func (g *GeoResolver) isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, proxy := range g.proxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GeoResolver) Lookup(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || len(g.ranges) == 0 {
+		return ""
+	}
+	addr = addr.Unmap()
+	i := sort.Search(len(g.ranges), func(i int) bool { return addr.Less(g.ranges[i].start) }) - 1
+	if i < 0 || g.ranges[i].end.Less(addr) {
+		return ""
+	}
+	if !domain.IsValidCountryCode(g.ranges[i].country) {
+		return ""
+	}
+	return g.ranges[i].country
+}
@@ -0,0 +1,156 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	progressBufferKeyPrefix = "watch_progress:"
+	progressDirtyKey        = "watch_progress:dirty"
+)
+
+// ProgressBufferInterface defines the interface for write-behind buffering of
+// playback progress, coalesced to the latest value per user and content
+type ProgressBufferInterface interface {
+	Put(ctx context.Context, history *domain.WatchHistory) error
+	Get(ctx context.Context, userID, contentID uuid.UUID) (*domain.WatchHistory, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WatchHistory, error)
+	Drain(ctx context.Context, maxUsers int) ([]*domain.WatchHistory, error)
+	Ack(ctx context.Context, histories []*domain.WatchHistory) error
+}
+
+// ProgressBuffer keeps one hash per user keyed by content ID, plus a set of
+// users with entries that still have to be flushed
+type ProgressBuffer struct {
+	client *redis.Client
+}
+
+func NewProgressBuffer(cache *Cache) *ProgressBuffer {
+	return &ProgressBuffer{client: cache.client}
+}
+
+// ackScript removes an entry only if it was not overwritten since it was
+// drained, and clears the user's dirty flag once nothing is left to flush
+var ackScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+end
+if redis.call('HLEN', KEYS[1]) == 0 then
+	redis.call('SREM', KEYS[2], ARGV[3])
+end
+return 0
+`)
+
+// forgetScript clears a user's dirty flag unless an entry was buffered since
+var forgetScript = redis.NewScript(`
+if redis.call('HLEN', KEYS[1]) == 0 then
+	redis.call('SREM', KEYS[2], ARGV[1])
+end
+return 0
+`)
+
+func progressBufferKey(userID string) string {
+	return progressBufferKeyPrefix + userID
+}
+
+func (b *ProgressBuffer) Put(ctx context.Context, history *domain.WatchHistory) error {
+	value, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	userID := history.UserID.String()
+	_, err = b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, progressBufferKey(userID), history.ContentID.String(), value)
+		pipe.SAdd(ctx, progressDirtyKey, userID)
+		return nil
+	})
+	return err
+}
+
+func (b *ProgressBuffer) Get(ctx context.Context, userID, contentID uuid.UUID) (*domain.WatchHistory, error) {
+	value, err := b.client.HGet(ctx, progressBufferKey(userID.String()), contentID.String()).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrWatchHistoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var history domain.WatchHistory
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+func (b *ProgressBuffer) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WatchHistory, error) {
+	values, err := b.client.HGetAll(ctx, progressBufferKey(userID.String())).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decodeProgress(values)
+}
+
+// Drain returns every buffered entry of up to maxUsers users awaiting a flush.
+// Entries stay buffered until they are acknowledged, so a failed flush is
+// retried on the next run.
+func (b *ProgressBuffer) Drain(ctx context.Context, maxUsers int) ([]*domain.WatchHistory, error) {
+	userIDs, err := b.client.SRandMemberN(ctx, progressDirtyKey, int64(maxUsers)).Result()
+	if err != nil {
+		return nil, err
+	}
+	pipe := b.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.HGetAll(ctx, progressBufferKey(userID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	var histories []*domain.WatchHistory
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			keys := []string{progressBufferKey(userIDs[i]), progressDirtyKey}
+			if err := forgetScript.Run(ctx, b.client, keys, userIDs[i]).Err(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		decoded, err := decodeProgress(cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, decoded...)
+	}
+	return histories, nil
+}
+
+func (b *ProgressBuffer) Ack(ctx context.Context, histories []*domain.WatchHistory) error {
+	pipe := b.client.Pipeline()
+	for _, history := range histories {
+		value, err := json.Marshal(history)
+		if err != nil {
+			return err
+		}
+		userID := history.UserID.String()
+		ackScript.Eval(ctx, pipe, []string{progressBufferKey(userID), progressDirtyKey}, history.ContentID.String(), value, userID)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func decodeProgress(values map[string]string) ([]*domain.WatchHistory, error) {
+	histories := make([]*domain.WatchHistory, 0, len(values))
+	for _, value := range values {
+		var history domain.WatchHistory
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			return nil, err
+		}
+		histories = append(histories, &history)
+	}
+	return histories, nil
+}
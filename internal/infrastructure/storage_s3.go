@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3SignedHeaders   = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// S3Storage talks to any S3-compatible API (AWS, MinIO, R2, ...) using
+// path-style addressing and SigV4 request signing
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Storage(endpoint, region, bucket, accessKey, secretKey, publicURL string) (*S3Storage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	if bucket == "" || accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("S3 bucket and credentials must be set")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	if publicURL == "" {
+		publicURL = strings.TrimSuffix(endpoint, "/") + "/" + bucket
+	}
+	return &S3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		client:    &http.Client{Timeout: 60 * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := validateObjectKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	return s.do(req)
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateObjectKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req)
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	return u.String()
+}
+
+func (s *S3Storage) do(req *http.Request) error {
+	s.sign(req)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// sign adds AWS SigV4 headers, the payload is left unsigned so bodies can stream
+func (s *S3Storage) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		s3SignedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKey, scope, s3SignedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+)
+
+// MaxFilterQueryLength caps the free text search of content listings
+const MaxFilterQueryLength = 100
+
+// ContentFilter narrows content listings, zero fields are not applied.
+// Duration bounds are in seconds and inclusive, CreatedAfter is inclusive and
+// CreatedBefore exclusive.
+type ContentFilter struct {
+	AccessLevels  []domain.AccessLevel
+	MinDuration   *int
+	MaxDuration   *int
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Published     *bool
+	Genre         string
+	Query         string
+}
+
+// PublishedOnly reports whether the listing is restricted to published titles
+func (f ContentFilter) PublishedOnly() bool {
+	return f.Published != nil && *f.Published
+}
+
+func (f ContentFilter) Validate() error {
+	for _, level := range f.AccessLevels {
+		if level != domain.AccessLevelFree && level != domain.AccessLevelBasic && level != domain.AccessLevelPremium {
+			return domain.ErrInvalidFilter
+		}
+	}
+	if (f.MinDuration != nil && *f.MinDuration < 0) || (f.MaxDuration != nil && *f.MaxDuration < 0) {
+		return domain.ErrInvalidFilter
+	}
+	if f.MinDuration != nil && f.MaxDuration != nil && *f.MinDuration > *f.MaxDuration {
+		return domain.ErrInvalidFilter
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedBefore.After(*f.CreatedAfter) {
+		return domain.ErrInvalidFilter
+	}
+	if utf8.RuneCountInString(f.Query) > MaxFilterQueryLength {
+		return domain.ErrInvalidFilter
+	}
+	return nil
+}
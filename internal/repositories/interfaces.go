@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
 	"github.com/google/uuid"
@@ -18,9 +19,40 @@ type UserRepository interface {
 type ContentRepository interface {
 	Create(ctx context.Context, content *domain.Content) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Content, error)
-	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.Content, int64, error)
+	GetByExternalID(ctx context.Context, externalID string) (*domain.Content, error)
+	List(ctx context.Context, filter ContentFilter, scope ContentScope, page Page) ([]*domain.Content, int64, error)
+	ListByPersonName(ctx context.Context, name string, filter ContentFilter, scope ContentScope, page Page) ([]*domain.Content, int64, error)
+	ListGenres(ctx context.Context, scope ContentScope, limit int) ([]string, error)
+	ListAvailableFromBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error)
+	ListAvailableUntilBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error)
 	Update(ctx context.Context, content *domain.Content) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListDeleted(ctx context.Context) ([]*domain.Content, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type ContentRevisionRepository interface {
+	Record(ctx context.Context, content *domain.Content, revision *domain.ContentRevision, discardDraft bool) error
+	SaveDraft(ctx context.Context, revision *domain.ContentRevision) error
+	GetDraft(ctx context.Context, contentID uuid.UUID) (*domain.ContentRevision, error)
+	GetByNumber(ctx context.Context, contentID uuid.UUID, number int) (*domain.ContentRevision, error)
+	ListByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentRevision, error)
+	DeleteDraft(ctx context.Context, contentID uuid.UUID) error
+}
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error)
+	Update(ctx context.Context, job *domain.ImportJob) error
+	FailUnfinished(ctx context.Context, message string, staleBefore, at time.Time) (int64, error)
+}
+
+// ContentScope narrows content listings to what a viewer can currently watch,
+// nil fields are not applied
+type ContentScope struct {
+	AvailableAt *time.Time
+	Country     *string
 }
 
 type PlanRepository interface {
@@ -30,6 +62,9 @@ type PlanRepository interface {
 	List(ctx context.Context, activeOnly bool) ([]*domain.Plan, error)
 	Update(ctx context.Context, plan *domain.Plan) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListDeleted(ctx context.Context) ([]*domain.Plan, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 type SubscriptionRepository interface {
@@ -45,8 +80,91 @@ type WatchHistoryRepository interface {
 	Create(ctx context.Context, history *domain.WatchHistory) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.WatchHistory, error)
 	GetByUserAndContent(ctx context.Context, userID, contentID uuid.UUID) (*domain.WatchHistory, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.WatchHistory, int64, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, page Page) ([]*domain.WatchHistory, int64, error)
 	GetContinueWatching(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.WatchHistory, error)
+	GetByUserAndContentIDs(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) ([]*domain.WatchHistory, error)
 	Update(ctx context.Context, history *domain.WatchHistory) error
+	UpdateProgressBatch(ctx context.Context, histories []*domain.WatchHistory) error
+	GetWatchTotals(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.WatchTotals, error)
+	GetTopGenres(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.GenreActivity, error)
+	GetTopTitles(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.TitleActivity, error)
+	GetMonthlyActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.MonthActivity, error)
+	GetActivityDays(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]time.Time, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type PersonRepository interface {
+	Create(ctx context.Context, person *domain.Person) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Person, error)
+	List(ctx context.Context, name string, limit, offset int) ([]*domain.Person, int64, error)
+	Update(ctx context.Context, person *domain.Person) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	CreateCredit(ctx context.Context, credit *domain.ContentCredit) error
+	GetCredit(ctx context.Context, contentID, personID uuid.UUID, role domain.PersonRole) (*domain.ContentCredit, error)
+	GetCreditsByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentCredit, error)
+	GetCreditsByPersonID(ctx context.Context, personID uuid.UUID) ([]*domain.ContentCredit, error)
+	DeleteCredit(ctx context.Context, contentID, creditID uuid.UUID) error
+}
+
+type RenditionRepository interface {
+	Create(ctx context.Context, rendition *domain.VideoRendition) error
+	GetByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.VideoRendition, error)
+	Delete(ctx context.Context, contentID, id uuid.UUID) error
+}
+
+type TrackRepository interface {
+	CreateSubtitle(ctx context.Context, track *domain.SubtitleTrack) error
+	GetSubtitle(ctx context.Context, contentID, id uuid.UUID) (*domain.SubtitleTrack, error)
+	ListSubtitles(ctx context.Context, contentID uuid.UUID) ([]*domain.SubtitleTrack, error)
+	DeleteSubtitle(ctx context.Context, contentID, id uuid.UUID) error
+	CreateAudioTrack(ctx context.Context, track *domain.AudioTrack) error
+	ListAudioTracks(ctx context.Context, contentID uuid.UUID) ([]*domain.AudioTrack, error)
+	DeleteAudioTrack(ctx context.Context, contentID, id uuid.UUID) error
+}
+
+type ReviewRepository interface {
+	Upsert(ctx context.Context, review *domain.Review) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Review, error)
+	GetByUserAndContent(ctx context.Context, userID, contentID uuid.UUID) (*domain.Review, error)
+	ListPublished(ctx context.Context, contentID uuid.UUID, limit, offset int) ([]*domain.Review, int64, error)
+	ListByStatus(ctx context.Context, status domain.ReviewStatus, limit, offset int) ([]*domain.Review, int64, error)
+	Update(ctx context.Context, review *domain.Review) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WatchlistRepository interface {
+	Add(ctx context.Context, item *domain.WatchlistItem) error
+	Exists(ctx context.Context, userID, contentID uuid.UUID) (bool, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistItem, error)
+	Reorder(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) error
+	Remove(ctx context.Context, userID, contentID uuid.UUID) error
+}
+
+type RecommendationRepository interface {
+	RebuildSimilarities(ctx context.Context, minCoViewers, perContent int) (int64, error)
+	ListSimilar(ctx context.Context, contentIDs []uuid.UUID, perContent int) ([]*domain.ContentSimilarity, error)
+	ListPopular(ctx context.Context, since time.Time, limit int) ([]*domain.Content, error)
+}
+
+type TrendingRepository interface {
+	ListTrending(ctx context.Context, since time.Time, country string, limit int) ([]*domain.ContentActivity, error)
+}
+
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *domain.Collection) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
+	List(ctx context.Context) ([]*domain.Collection, error)
+	ListVisible(ctx context.Context, at time.Time) ([]*domain.Collection, error)
+	Update(ctx context.Context, collection *domain.Collection) error
+	SetItems(ctx context.Context, collectionID uuid.UUID, contentIDs []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TranslationRepository interface {
+	ListContentTranslations(ctx context.Context, contentIDs []uuid.UUID, locales []string) ([]*domain.ContentTranslation, error)
+	UpsertContentTranslation(ctx context.Context, translation *domain.ContentTranslation) error
+	DeleteContentTranslation(ctx context.Context, contentID uuid.UUID, locale string) error
+	ListPlanTranslations(ctx context.Context, planIDs []uuid.UUID, locales []string) ([]*domain.PlanTranslation, error)
+	UpsertPlanTranslation(ctx context.Context, translation *domain.PlanTranslation) error
+	DeletePlanTranslation(ctx context.Context, planID uuid.UUID, locale string) error
 }
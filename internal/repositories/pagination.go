@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SortKey names a column list endpoints can be ordered and paged by
+type SortKey string
+
+const (
+	SortTitle         SortKey = "title"
+	SortCreatedAt     SortKey = "created_at"
+	SortDuration      SortKey = "duration"
+	SortLastWatchedAt SortKey = "last_watched_at"
+)
+
+// FormatValue renders a row's sort value for a cursor, ParseValue reverses it
+func (k SortKey) FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+func (k SortKey) ParseValue(raw string) (interface{}, error) {
+	switch k {
+	case SortCreatedAt, SortLastWatchedAt:
+		return time.Parse(time.RFC3339Nano, raw)
+	case SortDuration:
+		return strconv.Atoi(raw)
+	}
+	return raw, nil
+}
+
+// Keyset is the sort value and ID of the last row already returned,
+// ID breaks ties so rows sharing a sort value are neither skipped nor repeated
+type Keyset struct {
+	Value string
+	ID    uuid.UUID
+}
+
+// Page asks for Limit rows ordered by Sort, starting after After when set
+type Page struct {
+	Sort  SortKey
+	Desc  bool
+	Limit int
+	After *Keyset
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CollectionRepository struct{ db *gorm.DB }
+
+func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+func orderedItems(db *gorm.DB) *gorm.DB {
+	return db.Order("collection_items.position ASC")
+}
+
+func (r *CollectionRepository) Create(ctx context.Context, collection *domain.Collection) error {
+	return r.db.WithContext(ctx).Omit("Items").Create(collection).Error
+}
+
+func (r *CollectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	var collection domain.Collection
+	err := r.db.WithContext(ctx).Preload("Items", orderedItems).Preload("Items.Content").
+		Where("id = ?", id).First(&collection).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *CollectionRepository) List(ctx context.Context) ([]*domain.Collection, error) {
+	var collections []*domain.Collection
+	err := r.db.WithContext(ctx).Preload("Items", orderedItems).
+		Order("position ASC, created_at ASC").Find(&collections).Error
+	return collections, err
+}
+
+func (r *CollectionRepository) ListVisible(ctx context.Context, at time.Time) ([]*domain.Collection, error) {
+	var collections []*domain.Collection
+	err := r.db.WithContext(ctx).Preload("Items", orderedItems).Preload("Items.Content").
+		Where("(visible_from IS NULL OR visible_from <= ?) AND (visible_until IS NULL OR visible_until > ?)", at, at).
+		Order("position ASC, created_at ASC").Find(&collections).Error
+	return collections, err
+}
+
+func (r *CollectionRepository) Update(ctx context.Context, collection *domain.Collection) error {
+	return r.db.WithContext(ctx).Omit("Items").Save(collection).Error
+}
+
+// SetItems replaces the collection contents, positions follow contentIDs
+func (r *CollectionRepository) SetItems(ctx context.Context, collectionID uuid.UUID, contentIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.CollectionItem{}, "collection_id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		if len(contentIDs) == 0 {
+			return nil
+		}
+		items := make([]*domain.CollectionItem, len(contentIDs))
+		for i, contentID := range contentIDs {
+			items[i] = &domain.CollectionItem{CollectionID: collectionID, ContentID: contentID, Position: i + 1}
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+func (r *CollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.CollectionItem{}, "collection_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Collection{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrCollectionNotFound
+		}
+		return nil
+	})
+}
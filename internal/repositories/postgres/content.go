@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -28,27 +31,213 @@ func (r *ContentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &content, nil
 }
 
-func (r *ContentRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.Content, int64, error) {
+// GetByExternalID also finds trashed content, whose external ID stays reserved
+func (r *ContentRepository) GetByExternalID(ctx context.Context, externalID string) (*domain.Content, error) {
+	var content domain.Content
+	err := r.db.WithContext(ctx).Unscoped().Where("external_id = ?", externalID).First(&content).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrContentNotFound
+		}
+		return nil, err
+	}
+	return &content, nil
+}
+
+func (r *ContentRepository) List(ctx context.Context, filter repositories.ContentFilter, scope repositories.ContentScope, page repositories.Page) ([]*domain.Content, int64, error) {
 	var contents []*domain.Content
 	var total int64
-	query := r.db.WithContext(ctx).Model(&domain.Content{})
-	for key, value := range filters {
-		query = query.Where(key+" = ?", value)
+	paginate, err := keysetPage("contents", page)
+	if err != nil {
+		return nil, 0, err
 	}
+	query := r.db.WithContext(ctx).Model(&domain.Content{}).Scopes(contentScope(scope), contentFilter(filter))
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
-	err := query.Limit(limit).Offset(offset).Order("created_at DESC").Find(&contents).Error
+	if err := query.Scopes(paginate).Find(&contents).Error; err != nil {
+		return nil, 0, err
+	}
+	return contents, total, nil
+}
+
+func (r *ContentRepository) ListByPersonName(ctx context.Context, name string, filter repositories.ContentFilter, scope repositories.ContentScope, page repositories.Page) ([]*domain.Content, int64, error) {
+	var contents []*domain.Content
+	var total int64
+	paginate, err := keysetPage("contents", page)
 	if err != nil {
 		return nil, 0, err
 	}
+	credited := r.db.WithContext(ctx).Model(&domain.ContentCredit{}).
+		Select("content_credits.content_id").
+		Joins("JOIN people ON people.id = content_credits.person_id").
+		Where("people.name ILIKE ?", "%"+likeEscaper.Replace(name)+"%")
+	query := r.db.WithContext(ctx).Model(&domain.Content{}).Scopes(contentScope(scope), contentFilter(filter)).Where("id IN (?)", credited)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Scopes(paginate).Find(&contents).Error; err != nil {
+		return nil, 0, err
+	}
 	return contents, total, nil
 }
 
+// ListGenres returns the genres with the most published titles in scope
+func (r *ContentRepository) ListGenres(ctx context.Context, scope repositories.ContentScope, limit int) ([]string, error) {
+	var genres []string
+	err := r.db.WithContext(ctx).Model(&domain.Content{}).Scopes(contentScope(scope)).
+		Where("published = ? AND genre <> ''", true).
+		Group("genre").
+		Order("COUNT(*) DESC, genre ASC").
+		Limit(limit).
+		Pluck("genre", &genres).Error
+	return genres, err
+}
+
+func (r *ContentRepository) ListAvailableFromBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error) {
+	var contents []*domain.Content
+	err := r.db.WithContext(ctx).
+		Where("published = ? AND available_from > ? AND available_from <= ?", true, from, to).
+		Order("available_from ASC").
+		Find(&contents).Error
+	return contents, err
+}
+
+func (r *ContentRepository) ListAvailableUntilBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error) {
+	var contents []*domain.Content
+	err := r.db.WithContext(ctx).
+		Where("published = ? AND available_until > ? AND available_until <= ?", true, from, to).
+		Order("available_until ASC").
+		Find(&contents).Error
+	return contents, err
+}
+
 func (r *ContentRepository) Update(ctx context.Context, content *domain.Content) error {
 	return r.db.WithContext(ctx).Save(content).Error
 }
 
+// Delete moves content to the trash. Credits, media and list memberships are
+// kept so a restore is lossless; Purge removes what the content owns for good.
 func (r *ContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Content{}, "id = ?", id).Error
+	result := r.db.WithContext(ctx).Delete(&domain.Content{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrContentNotFound
+	}
+	return nil
+}
+
+func (r *ContentRepository) ListDeleted(ctx context.Context) ([]*domain.Content, error) {
+	var contents []*domain.Content
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&contents).Error
+	return contents, err
+}
+
+func (r *ContentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&domain.Content{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrContentNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes what belongs to content trashed before the cutoff.
+// Viewers' history, reviews and watchlists are kept, so a title they still
+// reference stays resolvable as no longer available and only its row is kept.
+func (r *ContentRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Content{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	var purged int64
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&domain.ContentCredit{},
+			&domain.VideoRendition{},
+			&domain.SubtitleTrack{},
+			&domain.AudioTrack{},
+			&domain.CollectionItem{},
+			&domain.ContentRevision{},
+			&domain.ContentTranslation{},
+		} {
+			if err := tx.Delete(model, "content_id IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&domain.ContentSimilarity{}, "content_id IN ? OR similar_content_id IN ?", ids, ids).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().
+			Where("id IN ?", ids).
+			Where("NOT EXISTS (SELECT 1 FROM watch_histories WHERE watch_histories.content_id = contents.id)").
+			Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.content_id = contents.id)").
+			Where("NOT EXISTS (SELECT 1 FROM watchlist_items WHERE watchlist_items.content_id = contents.id)").
+			Delete(&domain.Content{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+func contentScope(scope repositories.ContentScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if at := scope.AvailableAt; at != nil {
+			db = db.Where("(available_from IS NULL OR available_from <= ?) AND (available_until IS NULL OR available_until > ?)", *at, *at)
+		}
+		if country := scope.Country; country != nil {
+			db = db.Where("(allowed_countries = '' OR ',' || allowed_countries || ',' LIKE ?)", "%,"+*country+",%").
+				Where("',' || blocked_countries || ',' NOT LIKE ?", "%,"+*country+",%")
+		}
+		return db
+	}
+}
+
+func contentFilter(filter repositories.ContentFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(filter.AccessLevels) > 0 {
+			db = db.Where("access_level IN ?", filter.AccessLevels)
+		}
+		if filter.MinDuration != nil {
+			db = db.Where("duration_seconds >= ?", *filter.MinDuration)
+		}
+		if filter.MaxDuration != nil {
+			db = db.Where("duration_seconds <= ?", *filter.MaxDuration)
+		}
+		if filter.CreatedAfter != nil {
+			db = db.Where("created_at >= ?", *filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != nil {
+			db = db.Where("created_at < ?", *filter.CreatedBefore)
+		}
+		if filter.Published != nil {
+			db = db.Where("published = ?", *filter.Published)
+		}
+		if filter.Genre != "" {
+			db = db.Where("genre = ?", filter.Genre)
+		}
+		if filter.Query != "" {
+			pattern := "%" + likeEscaper.Replace(filter.Query) + "%"
+			db = db.Where("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+		}
+		return db
+	}
+}
+
+// likeEscaper makes user text match literally inside a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// unscoped lets a preload resolve soft deleted rows, so history and
+// subscriptions keep pointing at trashed content and plans
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
 }
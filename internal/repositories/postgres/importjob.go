@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository struct{ db *gorm.DB }
+
+func NewImportJobRepository(db *gorm.DB) *ImportJobRepository {
+	return &ImportJobRepository{db: db}
+}
+
+func (r *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *ImportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ImportJobRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// FailUnfinished closes jobs still pending or running that were last saved
+// before staleBefore, whose rows only lived in the memory of a process that is gone
+func (r *ImportJobRepository) FailUnfinished(ctx context.Context, message string, staleBefore, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ImportJob{}).
+		Where("status IN ?", []domain.ImportStatus{domain.ImportStatusPending, domain.ImportStatusRunning}).
+		Where("updated_at < ?", staleBefore).
+		Updates(map[string]interface{}{"status": domain.ImportStatusFailed, "message": message, "finished_at": at})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var sortColumns = map[repositories.SortKey]string{
+	repositories.SortTitle:         "title",
+	repositories.SortCreatedAt:     "created_at",
+	repositories.SortDuration:      "duration_seconds",
+	repositories.SortLastWatchedAt: "last_watched_at",
+}
+
+// keysetPage orders by the page sort column with the ID as tie breaker and,
+// after the first page, seeks past the keyset with a row comparison so the
+// query stays an index range scan however deep the client pages
+func keysetPage(table string, page repositories.Page) (func(*gorm.DB) *gorm.DB, error) {
+	column, ok := sortColumns[page.Sort]
+	if !ok {
+		return nil, domain.ErrInvalidSort
+	}
+	column = table + "." + column
+	id := table + ".id"
+	direction, comparison := "ASC", ">"
+	if page.Desc {
+		direction, comparison = "DESC", "<"
+	}
+	var after interface{}
+	if page.After != nil {
+		value, err := page.Sort.ParseValue(page.After.Value)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		after = value
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		if page.After != nil {
+			db = db.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column, id, comparison), after, page.After.ID)
+		}
+		return db.Order(column + " " + direction).Order(id + " " + direction).Limit(page.Limit)
+	}, nil
+}
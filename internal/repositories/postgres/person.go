@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonRepository struct{ db *gorm.DB }
+
+func NewPersonRepository(db *gorm.DB) *PersonRepository { return &PersonRepository{db: db} }
+
+func (r *PersonRepository) Create(ctx context.Context, person *domain.Person) error {
+	return r.db.WithContext(ctx).Create(person).Error
+}
+
+func (r *PersonRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Person, error) {
+	var person domain.Person
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&person).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPersonNotFound
+		}
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (r *PersonRepository) List(ctx context.Context, name string, limit, offset int) ([]*domain.Person, int64, error) {
+	var people []*domain.Person
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.Person{})
+	if name != "" {
+		query = query.Where("name ILIKE ?", "%"+likeEscaper.Replace(name)+"%")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Limit(limit).Offset(offset).Order("name ASC").Find(&people).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return people, total, nil
+}
+
+func (r *PersonRepository) Update(ctx context.Context, person *domain.Person) error {
+	return r.db.WithContext(ctx).Save(person).Error
+}
+
+func (r *PersonRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ContentCredit{}, "person_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Person{}, "id = ?", id).Error
+	})
+}
+
+func (r *PersonRepository) CreateCredit(ctx context.Context, credit *domain.ContentCredit) error {
+	return r.db.WithContext(ctx).Create(credit).Error
+}
+
+func (r *PersonRepository) GetCredit(ctx context.Context, contentID, personID uuid.UUID, role domain.PersonRole) (*domain.ContentCredit, error) {
+	var credit domain.ContentCredit
+	err := r.db.WithContext(ctx).
+		Where("content_id = ? AND person_id = ? AND role = ?", contentID, personID, role).
+		First(&credit).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCreditNotFound
+		}
+		return nil, err
+	}
+	return &credit, nil
+}
+
+func (r *PersonRepository) GetCreditsByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentCredit, error) {
+	var credits []*domain.ContentCredit
+	err := r.db.WithContext(ctx).Preload("Person").
+		Where("content_id = ?", contentID).
+		Order("billing_order ASC").
+		Find(&credits).Error
+	return credits, err
+}
+
+func (r *PersonRepository) GetCreditsByPersonID(ctx context.Context, personID uuid.UUID) ([]*domain.ContentCredit, error) {
+	var credits []*domain.ContentCredit
+	err := r.db.WithContext(ctx).Preload("Content").
+		Where("person_id = ?", personID).
+		Order("created_at DESC").
+		Find(&credits).Error
+	return credits, err
+}
+
+func (r *PersonRepository) DeleteCredit(ctx context.Context, contentID, creditID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.ContentCredit{}, "id = ? AND content_id = ?", creditID, contentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCreditNotFound
+	}
+	return nil
+}
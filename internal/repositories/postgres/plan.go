@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
 	"github.com/google/uuid"
@@ -54,6 +55,56 @@ func (r *PlanRepository) Update(ctx context.Context, plan *domain.Plan) error {
 	return r.db.WithContext(ctx).Save(plan).Error
 }
 
+// Delete moves a plan to the trash, existing subscriptions keep resolving it
 func (r *PlanRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Plan{}, "id = ?", id).Error
+	result := r.db.WithContext(ctx).Delete(&domain.Plan{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPlanNotFound
+	}
+	return nil
+}
+
+func (r *PlanRepository) ListDeleted(ctx context.Context) ([]*domain.Plan, error) {
+	var plans []*domain.Plan
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&plans).Error
+	return plans, err
+}
+
+func (r *PlanRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&domain.Plan{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPlanNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes plans trashed before the cutoff. Plans still
+// referenced by a subscription stay in the trash so billing history survives.
+func (r *PlanRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Plan{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM subscriptions WHERE subscriptions.plan_id = plans.id)").
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	var purged int64
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.PlanTranslation{}, "plan_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&domain.Plan{}, "id IN ?", ids)
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
 }
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecommendationRepository struct{ db *gorm.DB }
+
+func NewRecommendationRepository(db *gorm.DB) *RecommendationRepository {
+	return &RecommendationRepository{db: db}
+}
+
+// rebuildSimilaritiesSQL scores every pair of titles sharing at least
+// minCoViewers viewers by cosine similarity over the binary user vectors,
+// co_viewers / sqrt(viewers_a * viewers_b), and keeps the top pairs per title
+const rebuildSimilaritiesSQL = `
+WITH viewers AS (
+	SELECT content_id, COUNT(DISTINCT user_id) AS total
+	FROM watch_histories
+	GROUP BY content_id
+), pairs AS (
+	SELECT a.content_id, b.content_id AS similar_content_id, COUNT(DISTINCT a.user_id) AS co_viewers
+	FROM watch_histories a
+	JOIN watch_histories b ON b.user_id = a.user_id AND b.content_id <> a.content_id
+	GROUP BY a.content_id, b.content_id
+	HAVING COUNT(DISTINCT a.user_id) >= @min_co_viewers
+), scored AS (
+	SELECT p.content_id, p.similar_content_id, p.co_viewers,
+		p.co_viewers / SQRT(va.total::float8 * vb.total) AS score
+	FROM pairs p
+	JOIN viewers va ON va.content_id = p.content_id
+	JOIN viewers vb ON vb.content_id = p.similar_content_id
+), ranked AS (
+	SELECT *, ROW_NUMBER() OVER (PARTITION BY content_id ORDER BY score DESC, co_viewers DESC) AS rank
+	FROM scored
+)
+INSERT INTO content_similarities (content_id, similar_content_id, score, co_viewers, computed_at)
+SELECT content_id, similar_content_id, score, co_viewers, @computed_at
+FROM ranked
+WHERE rank <= @per_content`
+
+// RebuildSimilarities replaces the whole similarity table in one transaction
+// so readers never see a half-written batch
+func (r *RecommendationRepository) RebuildSimilarities(ctx context.Context, minCoViewers, perContent int) (int64, error) {
+	var rows int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM content_similarities").Error; err != nil {
+			return err
+		}
+		result := tx.Exec(rebuildSimilaritiesSQL, map[string]interface{}{
+			"min_co_viewers": minCoViewers,
+			"per_content":    perContent,
+			"computed_at":    time.Now(),
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	return rows, err
+}
+
+func (r *RecommendationRepository) ListSimilar(ctx context.Context, contentIDs []uuid.UUID, perContent int) ([]*domain.ContentSimilarity, error) {
+	var similarities []*domain.ContentSimilarity
+	if len(contentIDs) == 0 {
+		return similarities, nil
+	}
+	ranked := r.db.Model(&domain.ContentSimilarity{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY content_id ORDER BY score DESC) AS rank").
+		Where("content_id IN ?", contentIDs)
+	err := r.db.WithContext(ctx).Preload("SimilarContent").
+		Table("(?) AS ranked", ranked).
+		Where("rank <= ?", perContent).
+		Order("score DESC").
+		Find(&similarities).Error
+	return similarities, err
+}
+
+// ListPopular ranks content by distinct viewers active since the given time
+func (r *RecommendationRepository) ListPopular(ctx context.Context, since time.Time, limit int) ([]*domain.Content, error) {
+	var contents []*domain.Content
+	err := r.db.WithContext(ctx).Model(&domain.Content{}).
+		Joins("JOIN watch_histories ON watch_histories.content_id = contents.id AND watch_histories.last_watched_at >= ?", since).
+		Group("contents.id").
+		Order("COUNT(DISTINCT watch_histories.user_id) DESC, contents.created_at DESC").
+		Limit(limit).
+		Find(&contents).Error
+	return contents, err
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RenditionRepository struct{ db *gorm.DB }
+
+func NewRenditionRepository(db *gorm.DB) *RenditionRepository {
+	return &RenditionRepository{db: db}
+}
+
+func (r *RenditionRepository) Create(ctx context.Context, rendition *domain.VideoRendition) error {
+	return r.db.WithContext(ctx).Create(rendition).Error
+}
+
+func (r *RenditionRepository) GetByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.VideoRendition, error) {
+	var renditions []*domain.VideoRendition
+	err := r.db.WithContext(ctx).Where("content_id = ?", contentID).Order("bitrate_kbps ASC").Find(&renditions).Error
+	return renditions, err
+}
+
+func (r *RenditionRepository) Delete(ctx context.Context, contentID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.VideoRendition{}, "id = ? AND content_id = ?", id, contentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRenditionNotFound
+	}
+	return nil
+}
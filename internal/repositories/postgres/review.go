@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository struct{ db *gorm.DB }
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Upsert keeps one review per user and content and refreshes the content's
+// denormalized rating aggregates in the same transaction
+func (r *ReviewRepository) Upsert(ctx context.Context, review *domain.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "content_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rating", "body", "status", "moderation_note", "moderated_by", "moderated_at", "updated_at"}),
+		}).Create(review).Error
+		if err != nil {
+			return err
+		}
+		return refreshContentRating(tx, review.ContentID)
+	})
+}
+
+func (r *ReviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Review, error) {
+	var review domain.Review
+	if err := r.db.WithContext(ctx).First(&review, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) GetByUserAndContent(ctx context.Context, userID, contentID uuid.UUID) (*domain.Review, error) {
+	var review domain.Review
+	if err := r.db.WithContext(ctx).First(&review, "user_id = ? AND content_id = ?", userID, contentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListPublished returns approved reviews that carry text, newest first
+func (r *ReviewRepository) ListPublished(ctx context.Context, contentID uuid.UUID, limit, offset int) ([]*domain.Review, int64, error) {
+	var reviews []*domain.Review
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.Review{}).
+		Where("content_id = ? AND status = ? AND body <> ''", contentID, domain.ReviewStatusApproved)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Preload("User").Order("created_at DESC").Limit(limit).Offset(offset).Find(&reviews).Error
+	return reviews, total, err
+}
+
+func (r *ReviewRepository) ListByStatus(ctx context.Context, status domain.ReviewStatus, limit, offset int) ([]*domain.Review, int64, error) {
+	var reviews []*domain.Review
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.Review{}).Where("status = ?", status)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("created_at ASC").Limit(limit).Offset(offset).Find(&reviews).Error
+	return reviews, total, err
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, review *domain.Review) error {
+	return r.db.WithContext(ctx).Save(review).Error
+}
+
+func (r *ReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review domain.Review
+		if err := tx.Clauses(clause.Returning{}).Delete(&review, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if review.ID == uuid.Nil {
+			return domain.ErrReviewNotFound
+		}
+		return refreshContentRating(tx, review.ContentID)
+	})
+}
+
+func refreshContentRating(tx *gorm.DB, contentID uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE contents SET
+			rating_average = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM reviews WHERE content_id = ?), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE content_id = ?)
+		WHERE id = ?`, contentID, contentID, contentID).Error
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ContentRevisionRepository struct{ db *gorm.DB }
+
+func NewContentRevisionRepository(db *gorm.DB) *ContentRevisionRepository {
+	return &ContentRevisionRepository{db: db}
+}
+
+// Record stores a published revision under the next number. content, when
+// set, is saved in the same transaction and discardDraft drops the open draft.
+func (r *ContentRevisionRepository) Record(ctx context.Context, content *domain.Content, revision *domain.ContentRevision, discardDraft bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockContent(tx, revision.ContentID); err != nil {
+			return err
+		}
+		if content != nil {
+			if err := tx.Save(content).Error; err != nil {
+				return err
+			}
+		}
+		if discardDraft {
+			if err := tx.Delete(&domain.ContentRevision{}, "content_id = ? AND status = ?", revision.ContentID, domain.RevisionStatusDraft).Error; err != nil {
+				return err
+			}
+		}
+		number, err := nextRevisionNumber(tx, revision.ContentID)
+		if err != nil {
+			return err
+		}
+		revision.Number = number
+		return tx.Create(revision).Error
+	})
+}
+
+// SaveDraft replaces the content's open draft, keeping its number, or starts one
+func (r *ContentRevisionRepository) SaveDraft(ctx context.Context, revision *domain.ContentRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockContent(tx, revision.ContentID); err != nil {
+			return err
+		}
+		var existing domain.ContentRevision
+		err := tx.Where("content_id = ? AND status = ?", revision.ContentID, domain.RevisionStatusDraft).First(&existing).Error
+		if err == nil {
+			revision.ID = existing.ID
+			revision.Number = existing.Number
+			revision.CreatedAt = existing.CreatedAt
+			return tx.Save(revision).Error
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		number, err := nextRevisionNumber(tx, revision.ContentID)
+		if err != nil {
+			return err
+		}
+		revision.Number = number
+		return tx.Create(revision).Error
+	})
+}
+
+func (r *ContentRevisionRepository) GetDraft(ctx context.Context, contentID uuid.UUID) (*domain.ContentRevision, error) {
+	var revision domain.ContentRevision
+	err := r.db.WithContext(ctx).Where("content_id = ? AND status = ?", contentID, domain.RevisionStatusDraft).First(&revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *ContentRevisionRepository) GetByNumber(ctx context.Context, contentID uuid.UUID, number int) (*domain.ContentRevision, error) {
+	var revision domain.ContentRevision
+	err := r.db.WithContext(ctx).Where("content_id = ? AND number = ?", contentID, number).First(&revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *ContentRevisionRepository) ListByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentRevision, error) {
+	var revisions []*domain.ContentRevision
+	err := r.db.WithContext(ctx).Preload("Author").Where("content_id = ?", contentID).Order("number DESC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *ContentRevisionRepository) DeleteDraft(ctx context.Context, contentID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.ContentRevision{}, "content_id = ? AND status = ?", contentID, domain.RevisionStatusDraft)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDraftNotFound
+	}
+	return nil
+}
+
+// lockContent holds the content row until the transaction ends, so concurrent
+// edits of one content take revision numbers one after the other. A content
+// created in the same transaction has no row yet and nothing to race with.
+func lockContent(tx *gorm.DB, contentID uuid.UUID) error {
+	var ids []uuid.UUID
+	return tx.Unscoped().Model(&domain.Content{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", contentID).
+		Pluck("id", &ids).Error
+}
+
+// nextRevisionNumber must run after lockContent in the same transaction
+func nextRevisionNumber(tx *gorm.DB, contentID uuid.UUID) (int, error) {
+	var last int
+	err := tx.Model(&domain.ContentRevision{}).Where("content_id = ?", contentID).
+		Select("COALESCE(MAX(number), 0)").Scan(&last).Error
+	return last + 1, err
+}
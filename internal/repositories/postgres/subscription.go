@@ -20,7 +20,7 @@ func (r *SubscriptionRepository) Create(ctx context.Context, subscription *domai
 
 func (r *SubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Subscription, error) {
 	var subscription domain.Subscription
-	err := r.db.WithContext(ctx).Preload("User").Preload("Plan").Where("id = ?", id).First(&subscription).Error
+	err := r.db.WithContext(ctx).Preload("User").Preload("Plan", unscoped).Where("id = ?", id).First(&subscription).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrSubscriptionNotFound
@@ -33,7 +33,7 @@ func (r *SubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 func (r *SubscriptionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
 	var subscription domain.Subscription
 	err := r.db.WithContext(ctx).
-		Preload("Plan").
+		Preload("Plan", unscoped).
 		Where("user_id = ? AND is_active = ? AND status = ?",
 			userID, true, domain.SubscriptionStatusActive).
 		Order("end_date DESC").
@@ -49,7 +49,7 @@ func (r *SubscriptionRepository) GetActiveByUserID(ctx context.Context, userID u
 
 func (r *SubscriptionRepository) GetHistoryByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Subscription, error) {
 	var subscriptions []*domain.Subscription
-	err := r.db.WithContext(ctx).Preload("Plan").Where("user_id = ?", userID).Order("created_at DESC").Find(&subscriptions).Error
+	err := r.db.WithContext(ctx).Preload("Plan", unscoped).Where("user_id = ?", userID).Order("created_at DESC").Find(&subscriptions).Error
 	return subscriptions, err
 }
 
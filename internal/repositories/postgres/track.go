@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrackRepository struct{ db *gorm.DB }
+
+func NewTrackRepository(db *gorm.DB) *TrackRepository {
+	return &TrackRepository{db: db}
+}
+
+func (r *TrackRepository) CreateSubtitle(ctx context.Context, track *domain.SubtitleTrack) error {
+	return r.db.WithContext(ctx).Create(track).Error
+}
+
+func (r *TrackRepository) GetSubtitle(ctx context.Context, contentID, id uuid.UUID) (*domain.SubtitleTrack, error) {
+	var track domain.SubtitleTrack
+	if err := r.db.WithContext(ctx).First(&track, "id = ? AND content_id = ?", id, contentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTrackNotFound
+		}
+		return nil, err
+	}
+	return &track, nil
+}
+
+// ListSubtitles skips the hosted file bodies, which are only served one at a time
+func (r *TrackRepository) ListSubtitles(ctx context.Context, contentID uuid.UUID) ([]*domain.SubtitleTrack, error) {
+	var tracks []*domain.SubtitleTrack
+	err := r.db.WithContext(ctx).Omit("body").Where("content_id = ?", contentID).Order("language ASC, is_forced ASC, is_sdh ASC").Find(&tracks).Error
+	return tracks, err
+}
+
+func (r *TrackRepository) DeleteSubtitle(ctx context.Context, contentID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.SubtitleTrack{}, "id = ? AND content_id = ?", id, contentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTrackNotFound
+	}
+	return nil
+}
+
+func (r *TrackRepository) CreateAudioTrack(ctx context.Context, track *domain.AudioTrack) error {
+	return r.db.WithContext(ctx).Create(track).Error
+}
+
+func (r *TrackRepository) ListAudioTracks(ctx context.Context, contentID uuid.UUID) ([]*domain.AudioTrack, error) {
+	var tracks []*domain.AudioTrack
+	err := r.db.WithContext(ctx).Where("content_id = ?", contentID).Order("is_default DESC, language ASC").Find(&tracks).Error
+	return tracks, err
+}
+
+func (r *TrackRepository) DeleteAudioTrack(ctx context.Context, contentID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.AudioTrack{}, "id = ? AND content_id = ?", id, contentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTrackNotFound
+	}
+	return nil
+}
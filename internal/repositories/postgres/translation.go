@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepository struct{ db *gorm.DB }
+
+func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+// ListContentTranslations loads translations for the given content, all locales when locales is empty
+func (r *TranslationRepository) ListContentTranslations(ctx context.Context, contentIDs []uuid.UUID, locales []string) ([]*domain.ContentTranslation, error) {
+	var translations []*domain.ContentTranslation
+	if len(contentIDs) == 0 {
+		return translations, nil
+	}
+	query := r.db.WithContext(ctx).Where("content_id IN ?", contentIDs)
+	if len(locales) > 0 {
+		query = query.Where("locale IN ?", locales)
+	}
+	err := query.Order("locale ASC").Find(&translations).Error
+	return translations, err
+}
+
+// UpsertContentTranslation keeps one translation per content and locale,
+// returning the stored row so an update reports its original ID
+func (r *TranslationRepository) UpsertContentTranslation(ctx context.Context, translation *domain.ContentTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "updated_at"}),
+	}, clause.Returning{}).Create(translation).Error
+}
+
+func (r *TranslationRepository) DeleteContentTranslation(ctx context.Context, contentID uuid.UUID, locale string) error {
+	result := r.db.WithContext(ctx).Delete(&domain.ContentTranslation{}, "content_id = ? AND locale = ?", contentID, locale)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTranslationNotFound
+	}
+	return nil
+}
+
+// ListPlanTranslations loads translations for the given plans, all locales when locales is empty
+func (r *TranslationRepository) ListPlanTranslations(ctx context.Context, planIDs []uuid.UUID, locales []string) ([]*domain.PlanTranslation, error) {
+	var translations []*domain.PlanTranslation
+	if len(planIDs) == 0 {
+		return translations, nil
+	}
+	query := r.db.WithContext(ctx).Where("plan_id IN ?", planIDs)
+	if len(locales) > 0 {
+		query = query.Where("locale IN ?", locales)
+	}
+	err := query.Order("locale ASC").Find(&translations).Error
+	return translations, err
+}
+
+// UpsertPlanTranslation keeps one translation per plan and locale
+func (r *TranslationRepository) UpsertPlanTranslation(ctx context.Context, translation *domain.PlanTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "plan_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+	}, clause.Returning{}).Create(translation).Error
+}
+
+func (r *TranslationRepository) DeletePlanTranslation(ctx context.Context, planID uuid.UUID, locale string) error {
+	result := r.db.WithContext(ctx).Delete(&domain.PlanTranslation{}, "plan_id = ? AND locale = ?", planID, locale)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTranslationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrendingRepository struct{ db *gorm.DB }
+
+func NewTrendingRepository(db *gorm.DB) *TrendingRepository {
+	return &TrendingRepository{db: db}
+}
+
+// ListTrending aggregates watch activity since the given time over content
+// currently watchable in country. Histories only keep their latest state, so a
+// title counts once per viewer who touched it in the window. With a known
+// country only activity recorded from that country is counted.
+func (r *TrendingRepository) ListTrending(ctx context.Context, since time.Time, country string, limit int) ([]*domain.ContentActivity, error) {
+	now := time.Now()
+	query := r.db.WithContext(ctx).Table("watch_histories").
+		Select(`watch_histories.content_id,
+			COUNT(DISTINCT watch_histories.user_id) AS unique_viewers,
+			COUNT(*) FILTER (WHERE watch_histories.status = ?) AS completions,
+			COALESCE(SUM(watch_histories.watched_seconds), 0) AS watch_seconds`, domain.WatchStatusCompleted).
+		Joins("JOIN contents ON contents.id = watch_histories.content_id").
+		Where("watch_histories.last_watched_at >= ? AND contents.published = ? AND contents.deleted_at IS NULL", since, true).
+		Scopes(contentScope(repositories.ContentScope{AvailableAt: &now, Country: &country}))
+	if country != "" {
+		query = query.Where("watch_histories.country = ?", country)
+	}
+	var activity []*domain.ContentActivity
+	err := query.Group("watch_histories.content_id").
+		Order("unique_viewers DESC, completions DESC, watch_seconds DESC").
+		Limit(limit).
+		Scan(&activity).Error
+	if err != nil || len(activity) == 0 {
+		return activity, err
+	}
+
+	ids := make([]uuid.UUID, len(activity))
+	for i, entry := range activity {
+		ids[i] = entry.ContentID
+	}
+	var contents []*domain.Content
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*domain.Content, len(contents))
+	for _, content := range contents {
+		byID[content.ID] = content
+	}
+	for _, entry := range activity {
+		entry.Content = byID[entry.ContentID]
+	}
+	return activity, nil
+}
@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -20,7 +23,7 @@ func (r *WatchHistoryRepository) Create(ctx context.Context, history *domain.Wat
 
 func (r *WatchHistoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WatchHistory, error) {
 	var history domain.WatchHistory
-	err := r.db.WithContext(ctx).Preload("Content").Where("id = ?", id).First(&history).Error
+	err := r.db.WithContext(ctx).Preload("Content", unscoped).Where("id = ?", id).First(&history).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrWatchHistoryNotFound
@@ -42,14 +45,18 @@ func (r *WatchHistoryRepository) GetByUserAndContent(ctx context.Context, userID
 	return &history, nil
 }
 
-func (r *WatchHistoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.WatchHistory, int64, error) {
+func (r *WatchHistoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page repositories.Page) ([]*domain.WatchHistory, int64, error) {
 	var histories []*domain.WatchHistory
 	var total int64
+	paginate, err := keysetPage("watch_histories", page)
+	if err != nil {
+		return nil, 0, err
+	}
 	query := r.db.WithContext(ctx).Model(&domain.WatchHistory{}).Where("user_id = ?", userID)
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
-	err := query.Preload("Content").Limit(limit).Offset(offset).Order("last_watched_at DESC").Find(&histories).Error
+	err = query.Preload("Content", unscoped).Scopes(paginate).Find(&histories).Error
 	return histories, total, err
 }
 
@@ -58,16 +65,65 @@ func (r *WatchHistoryRepository) GetContinueWatching(ctx context.Context, userID
 	err := r.db.WithContext(ctx).Preload("Content").
 		Where("user_id = ? AND status != ?", userID, domain.WatchStatusCompleted).
 		Where("watched_seconds > 0").
+		Where("content_id IN (?)", r.db.Model(&domain.Content{}).Select("id")).
 		Order("last_watched_at DESC").
 		Limit(limit).
 		Find(&histories).Error
 	return histories, err
 }
 
+func (r *WatchHistoryRepository) GetByUserAndContentIDs(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) ([]*domain.WatchHistory, error) {
+	var histories []*domain.WatchHistory
+	if len(contentIDs) == 0 {
+		return histories, nil
+	}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND content_id IN ?", userID, contentIDs).Find(&histories).Error
+	return histories, err
+}
+
 func (r *WatchHistoryRepository) Update(ctx context.Context, history *domain.WatchHistory) error {
 	return r.db.WithContext(ctx).Save(history).Error
 }
 
+// progressBatchRows keeps a batch update well below the bind parameter limit
+const progressBatchRows = 1000
+
+const updateProgressBatchSQL = `
+	UPDATE watch_histories AS w SET
+		watched_seconds = v.watched_seconds,
+		status = v.status,
+		rewatch_count = v.rewatch_count,
+		completed_at = v.completed_at,
+		device_id = v.device_id,
+		last_watched_at = v.last_watched_at,
+		country = v.country,
+		updated_at = NOW()
+	FROM (VALUES %s)
+		AS v(id, watched_seconds, status, rewatch_count, completed_at, device_id, last_watched_at, country)
+	WHERE w.id = v.id AND w.last_watched_at <= v.last_watched_at`
+
+// UpdateProgressBatch writes the progress of many existing rows with one
+// statement per chunk. A row already holding newer progress is left untouched,
+// and rows deleted in the meantime are not recreated.
+func (r *WatchHistoryRepository) UpdateProgressBatch(ctx context.Context, histories []*domain.WatchHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(histories); start += progressBatchRows {
+			chunk := histories[start:min(start+progressBatchRows, len(histories))]
+			rows := make([]string, len(chunk))
+			args := make([]interface{}, 0, len(chunk)*8)
+			for i, history := range chunk {
+				rows[i] = "(?::uuid, ?::integer, ?::varchar, ?::integer, ?::timestamptz, ?::varchar, ?::timestamptz, ?::varchar)"
+				args = append(args, history.ID, history.WatchedSeconds, history.Status, history.RewatchCount,
+					history.CompletedAt, history.DeviceID, history.LastWatchedAt, history.Country)
+			}
+			if err := tx.Exec(fmt.Sprintf(updateProgressBatchSQL, strings.Join(rows, ", ")), args...).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *WatchHistoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.WatchHistory{}, "id = ?", id).Error
 }
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WatchlistRepository struct{ db *gorm.DB }
+
+func NewWatchlistRepository(db *gorm.DB) *WatchlistRepository {
+	return &WatchlistRepository{db: db}
+}
+
+// Add appends the item to the end of the user's list
+func (r *WatchlistRepository) Add(ctx context.Context, item *domain.WatchlistItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&domain.WatchlistItem{}).Where("user_id = ?", item.UserID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		item.Position = last + 1
+		return tx.Create(item).Error
+	})
+}
+
+func (r *WatchlistRepository) Exists(ctx context.Context, userID, contentID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.WatchlistItem{}).
+		Where("user_id = ? AND content_id = ?", userID, contentID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *WatchlistRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistItem, error) {
+	var items []*domain.WatchlistItem
+	err := r.db.WithContext(ctx).Preload("Content").Where("user_id = ?", userID).Order("position ASC").Find(&items).Error
+	return items, err
+}
+
+// Reorder rewrites positions 1..n following contentIDs
+func (r *WatchlistRepository) Reorder(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, contentID := range contentIDs {
+			if err := tx.Model(&domain.WatchlistItem{}).
+				Where("user_id = ? AND content_id = ?", userID, contentID).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *WatchlistRepository) Remove(ctx context.Context, userID, contentID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&domain.WatchlistItem{}, "user_id = ? AND content_id = ?", userID, contentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrWatchlistItemNotFound
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Histories only keep their latest state, so viewing is attributed to the
+// period a title was last watched in. Each finished rewatch counts the full
+// runtime on top of the current position.
+const watchSecondsSQL = "watch_histories.watched_seconds + watch_histories.rewatch_count * watch_histories.total_seconds"
+
+const completedSQL = "(watch_histories.completed_at IS NOT NULL OR watch_histories.status = ?)"
+
+const activityDaysSQL = `
+	SELECT DATE(created_at AT TIME ZONE 'UTC') AS day FROM watch_histories
+	WHERE user_id = @user AND created_at >= @from AND created_at < @to
+	UNION
+	SELECT DATE(last_watched_at AT TIME ZONE 'UTC') FROM watch_histories
+	WHERE user_id = @user AND last_watched_at >= @from AND last_watched_at < @to
+	UNION
+	SELECT DATE(completed_at AT TIME ZONE 'UTC') FROM watch_histories
+	WHERE user_id = @user AND completed_at >= @from AND completed_at < @to
+	ORDER BY day`
+
+func viewerActivity(userID uuid.UUID, from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("watch_histories.user_id = ? AND watch_histories.last_watched_at >= ? AND watch_histories.last_watched_at < ?", userID, from, to)
+	}
+}
+
+func (r *WatchHistoryRepository) GetWatchTotals(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.WatchTotals, error) {
+	var totals domain.WatchTotals
+	err := r.db.WithContext(ctx).Table("watch_histories").
+		Select(`COALESCE(SUM(`+watchSecondsSQL+`), 0) AS watch_seconds,
+			COUNT(*) AS titles_watched,
+			COUNT(*) FILTER (WHERE `+completedSQL+`) AS titles_completed`, domain.WatchStatusCompleted).
+		Scopes(viewerActivity(userID, from, to)).
+		Scan(&totals).Error
+	return &totals, err
+}
+
+func (r *WatchHistoryRepository) GetTopGenres(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.GenreActivity, error) {
+	var genres []*domain.GenreActivity
+	err := r.db.WithContext(ctx).Table("watch_histories").
+		Select(`contents.genre, COUNT(*) AS titles, COALESCE(SUM(` + watchSecondsSQL + `), 0) AS watch_seconds`).
+		Joins("JOIN contents ON contents.id = watch_histories.content_id").
+		Scopes(viewerActivity(userID, from, to)).
+		Where("contents.genre <> ''").
+		Group("contents.genre").
+		Order("watch_seconds DESC, titles DESC, contents.genre").
+		Limit(limit).
+		Scan(&genres).Error
+	return genres, err
+}
+
+func (r *WatchHistoryRepository) GetTopTitles(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.TitleActivity, error) {
+	var titles []*domain.TitleActivity
+	err := r.db.WithContext(ctx).Table("watch_histories").
+		Select(`watch_histories.content_id,
+			`+watchSecondsSQL+` AS watch_seconds,
+			watch_histories.rewatch_count,
+			`+completedSQL+` AS completed`, domain.WatchStatusCompleted).
+		Scopes(viewerActivity(userID, from, to)).
+		Order("watch_seconds DESC, watch_histories.last_watched_at DESC").
+		Limit(limit).
+		Scan(&titles).Error
+	if err != nil || len(titles) == 0 {
+		return titles, err
+	}
+
+	ids := make([]uuid.UUID, len(titles))
+	for i, title := range titles {
+		ids[i] = title.ContentID
+	}
+	var contents []*domain.Content
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*domain.Content, len(contents))
+	for _, content := range contents {
+		byID[content.ID] = content
+	}
+	for _, title := range titles {
+		title.Content = byID[title.ContentID]
+	}
+	return titles, nil
+}
+
+func (r *WatchHistoryRepository) GetMonthlyActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.MonthActivity, error) {
+	var months []*domain.MonthActivity
+	err := r.db.WithContext(ctx).Table("watch_histories").
+		Select(`EXTRACT(MONTH FROM watch_histories.last_watched_at AT TIME ZONE 'UTC')::int AS month,
+			COUNT(*) AS titles,
+			COALESCE(SUM(` + watchSecondsSQL + `), 0) AS watch_seconds`).
+		Scopes(viewerActivity(userID, from, to)).
+		Group("month").
+		Order("month").
+		Scan(&months).Error
+	return months, err
+}
+
+// GetActivityDays lists the UTC days on which the user started, last watched
+// or finished a title
+func (r *WatchHistoryRepository) GetActivityDays(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]time.Time, error) {
+	rows, err := r.db.WithContext(ctx).Raw(activityDaysSQL, map[string]interface{}{
+		"user": userID,
+		"from": from,
+		"to":   to,
+	}).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
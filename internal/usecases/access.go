@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// userAccessLevel resolves the highest access level a caller is entitled to,
+// anonymous callers and users without a live subscription only get free content
+func userAccessLevel(ctx context.Context, subscriptionRepo repositories.SubscriptionRepository, userID *uuid.UUID) (domain.AccessLevel, error) {
+	plan, err := userPlan(ctx, subscriptionRepo, userID)
+	if err != nil {
+		return "", err
+	}
+	if plan == nil {
+		return domain.AccessLevelFree, nil
+	}
+	return plan.AccessLevel, nil
+}
+
+// userMaxResolution caps streams at the plan resolution, viewers without a plan get the lowest tier
+func userMaxResolution(ctx context.Context, subscriptionRepo repositories.SubscriptionRepository, userID *uuid.UUID) (domain.Resolution, error) {
+	plan, err := userPlan(ctx, subscriptionRepo, userID)
+	if err != nil {
+		return "", err
+	}
+	if plan == nil {
+		return domain.Resolution480p, nil
+	}
+	return plan.MaxResolution()
+}
+
+// userPlan returns the plan of the caller's live subscription or nil when there is none
+func userPlan(ctx context.Context, subscriptionRepo repositories.SubscriptionRepository, userID *uuid.UUID) (*domain.Plan, error) {
+	if userID == nil {
+		return nil, nil
+	}
+	subscription, err := subscriptionRepo.GetActiveByUserID(ctx, *userID)
+	if err != nil {
+		if err == domain.ErrSubscriptionNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !subscription.IsActive || subscription.IsExpired() {
+		return nil, nil
+	}
+	return subscription.Plan, nil
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+)
+
+const availabilityWatermarkKey = "availability:last_run"
+
+// AvailabilityScheduler polls for content whose availability window opened or
+// closed since the previous tick and publishes the matching content events.
+// The last processed time is kept in the cache, so windows passing while the
+// process is down are picked up on the first tick after a restart.
+type AvailabilityScheduler struct {
+	contentRepo repositories.ContentRepository
+	events      infrastructure.EventBusInterface
+	cache       infrastructure.CacheInterface
+	lastRun     time.Time
+}
+
+func NewAvailabilityScheduler(contentRepo repositories.ContentRepository, events infrastructure.EventBusInterface, cache infrastructure.CacheInterface) *AvailabilityScheduler {
+	return &AvailabilityScheduler{contentRepo: contentRepo, events: events, cache: cache}
+}
+
+func (s *AvailabilityScheduler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Tick(ctx, now); err != nil {
+				log.Printf("Availability scheduler tick failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *AvailabilityScheduler) Tick(ctx context.Context, now time.Time) error {
+	if s.lastRun.IsZero() {
+		lastRun, err := s.loadWatermark(ctx, now)
+		if err != nil {
+			return err
+		}
+		s.lastRun = lastRun
+	}
+	published, err := s.contentRepo.ListAvailableFromBetween(ctx, s.lastRun, now)
+	if err != nil {
+		return err
+	}
+	expired, err := s.contentRepo.ListAvailableUntilBetween(ctx, s.lastRun, now)
+	if err != nil {
+		return err
+	}
+	for _, content := range published {
+		s.events.Publish(ctx, domain.ContentEvent{Type: domain.ContentEventPublished, ContentID: content.ID, OccurredAt: *content.AvailableFrom})
+	}
+	for _, content := range expired {
+		s.events.Publish(ctx, domain.ContentEvent{Type: domain.ContentEventExpired, ContentID: content.ID, OccurredAt: *content.AvailableUntil})
+	}
+	s.lastRun = now
+	if err := s.cache.Set(ctx, availabilityWatermarkKey, now.UTC().Format(time.RFC3339Nano), 0); err != nil {
+		log.Printf("store availability watermark: %v", err)
+	}
+	return nil
+}
+
+// loadWatermark resumes from the last stored run. Without one, as on the very
+// first start, only windows passing from now on are announced.
+func (s *AvailabilityScheduler) loadWatermark(ctx context.Context, now time.Time) (time.Time, error) {
+	stored, err := s.cache.Get(ctx, availabilityWatermarkKey)
+	if infrastructure.IsCacheMiss(err) {
+		return now, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	lastRun, err := time.Parse(time.RFC3339Nano, stored)
+	if err != nil {
+		log.Printf("ignore invalid availability watermark %q: %v", stored, err)
+		return now, nil
+	}
+	return lastRun, nil
+}
@@ -0,0 +1,523 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxCatalogImportBytes = 20 << 20
+	MaxCatalogImportRows  = 10000
+	// only the first errors are kept on the job, Failed still counts every row
+	maxImportRowErrors  = 500
+	importProgressEvery = 100
+	catalogExportBatch  = 500
+	// a running import saves the job at least this often, so other instances
+	// can tell it apart from one whose process is gone
+	importHeartbeatEvery = 30 * time.Second
+	importStaleAfter     = 5 * time.Minute
+)
+
+// catalogColumns is the CSV layout shared by import and export. Exports lead
+// with an id column, which imports ignore.
+var catalogColumns = []string{
+	"external_id", "title", "description", "access_level", "content_type", "genre", "duration_seconds",
+	"thumbnail_url", "trailer_url", "video_url", "published", "available_from", "available_until",
+	"allowed_countries", "blocked_countries",
+}
+
+var requiredCatalogColumns = []string{"external_id", "title", "access_level", "duration_seconds"}
+
+// CatalogRecord is one title of an import or export file, identified across
+// systems by the partner's external ID
+type CatalogRecord struct {
+	ExternalID string `json:"external_id"`
+	CreateContentInput
+}
+
+type catalogExportRecord struct {
+	ID uuid.UUID `json:"id"`
+	CatalogRecord
+}
+
+// CatalogRow is a parsed import row. Err is set when the row could not be
+// read, the rest of the file is still imported.
+type CatalogRow struct {
+	Number int
+	Record CatalogRecord
+	Err    error
+}
+
+type CatalogUseCase struct {
+	contentUseCase *ContentUseCase
+	contentRepo    repositories.ContentRepository
+	importJobRepo  repositories.ImportJobRepository
+	imports        sync.WaitGroup
+}
+
+func NewCatalogUseCase(contentUseCase *ContentUseCase, contentRepo repositories.ContentRepository, importJobRepo repositories.ImportJobRepository) *CatalogUseCase {
+	return &CatalogUseCase{contentUseCase: contentUseCase, contentRepo: contentRepo, importJobRepo: importJobRepo}
+}
+
+// StartImport parses the file, queues a job and imports it in the background.
+// Malformed files are rejected up front, invalid rows are reported on the job.
+func (uc *CatalogUseCase) StartImport(ctx context.Context, authorID uuid.UUID, format domain.CatalogFormat, data []byte, dryRun bool) (*domain.ImportJob, error) {
+	rows, err := ParseCatalog(format, data)
+	if err != nil {
+		return nil, err
+	}
+	job := &domain.ImportJob{
+		ID:        uuid.New(),
+		AuthorID:  authorID,
+		Format:    format,
+		DryRun:    dryRun,
+		Status:    domain.ImportStatusPending,
+		TotalRows: len(rows),
+	}
+	if err := uc.importJobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	queued := *job
+	uc.imports.Add(1)
+	go func() {
+		defer uc.imports.Done()
+		uc.RunImport(context.WithoutCancel(ctx), job, rows)
+	}()
+	return &queued, nil
+}
+
+// WaitForImports blocks until background imports finish or ctx is done. Jobs
+// cut off by shutdown are failed by FailInterruptedImports once they go stale.
+func (uc *CatalogUseCase) WaitForImports(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		uc.imports.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StartImportSweep fails interrupted imports now and then on every interval,
+// catching jobs whose instance went away after this one started
+func (uc *CatalogUseCase) StartImportSweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if failed, err := uc.FailInterruptedImports(ctx, time.Now()); err != nil {
+			log.Printf("Failed to close interrupted import jobs: %v", err)
+		} else if failed > 0 {
+			log.Printf("Marked %d interrupted import jobs as failed", failed)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FailInterruptedImports marks pending or running jobs that have not saved
+// progress for importStaleAfter as failed. Their process is gone and the rows
+// cannot be recovered, while jobs still running on other instances keep
+// saving within the heartbeat and are left alone.
+func (uc *CatalogUseCase) FailInterruptedImports(ctx context.Context, now time.Time) (int64, error) {
+	return uc.importJobRepo.FailUnfinished(ctx, "import interrupted by a server restart, upload the file again", now.Add(-importStaleAfter), now)
+}
+
+func (uc *CatalogUseCase) GetImportJob(ctx context.Context, jobID uuid.UUID) (*domain.ImportJob, error) {
+	return uc.importJobRepo.GetByID(ctx, jobID)
+}
+
+// RunImport upserts each row by external ID and records the outcome on the
+// job. A dry run validates and classifies rows without writing content.
+func (uc *CatalogUseCase) RunImport(ctx context.Context, job *domain.ImportJob, rows []CatalogRow) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Import job %s panicked: %v\n%s", job.ID, r, debug.Stack())
+			finished := time.Now()
+			job.Status = domain.ImportStatusFailed
+			job.Message = fmt.Sprintf("import aborted after %d rows by an internal error", job.Processed)
+			job.FinishedAt = &finished
+			uc.saveJob(ctx, job)
+		}
+	}()
+	started := time.Now()
+	job.Status = domain.ImportStatusRunning
+	job.StartedAt = &started
+	uc.saveJob(ctx, job)
+	saved := started
+
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		outcome, err := uc.importRow(ctx, job, row, seen)
+		switch {
+		case err != nil:
+			job.Failed++
+			if len(job.Errors) < maxImportRowErrors {
+				job.Errors = append(job.Errors, domain.ImportRowError{Row: row.Number, ExternalID: row.Record.ExternalID, Error: err.Error()})
+			}
+		case outcome == importCreated:
+			job.Created++
+		case outcome == importUpdated:
+			job.Updated++
+		default:
+			job.Unchanged++
+		}
+		job.Processed++
+		if job.Processed%importProgressEvery == 0 || time.Since(saved) >= importHeartbeatEvery {
+			uc.saveJob(ctx, job)
+			saved = time.Now()
+		}
+	}
+
+	finished := time.Now()
+	job.Status = domain.ImportStatusCompleted
+	job.FinishedAt = &finished
+	uc.saveJob(ctx, job)
+}
+
+type importOutcome int
+
+const (
+	importUnchanged importOutcome = iota
+	importCreated
+	importUpdated
+)
+
+func (uc *CatalogUseCase) importRow(ctx context.Context, job *domain.ImportJob, row CatalogRow, seen map[string]int) (importOutcome, error) {
+	if row.Err != nil {
+		return importUnchanged, row.Err
+	}
+	snapshot, err := row.Record.validate()
+	if err != nil {
+		return importUnchanged, err
+	}
+	externalID := row.Record.ExternalID
+	if first, ok := seen[externalID]; ok {
+		return importUnchanged, fmt.Errorf("external_id already used on row %d", first)
+	}
+	seen[externalID] = row.Number
+
+	existing, err := uc.contentRepo.GetByExternalID(ctx, externalID)
+	if err == domain.ErrContentNotFound {
+		if !job.DryRun {
+			if _, err := uc.contentUseCase.createContent(ctx, job.AuthorID, snapshot, &externalID); err != nil {
+				return importUnchanged, err
+			}
+		}
+		return importCreated, nil
+	}
+	if err != nil {
+		return importUnchanged, err
+	}
+	if existing.IsDeleted() {
+		return importUnchanged, errors.New("content with this external_id is in the trash")
+	}
+	if len(existing.Snapshot().Diff(snapshot)) == 0 {
+		return importUnchanged, nil
+	}
+	if !job.DryRun {
+		if _, err := uc.contentUseCase.publishSnapshot(ctx, job.AuthorID, existing, snapshot, nil, false); err != nil {
+			return importUnchanged, err
+		}
+	}
+	return importUpdated, nil
+}
+
+// saveJob persists progress, a failure only costs the status endpoint an update
+func (uc *CatalogUseCase) saveJob(ctx context.Context, job *domain.ImportJob) {
+	if err := uc.importJobRepo.Update(ctx, job); err != nil {
+		log.Printf("Failed to save import job %s: %v", job.ID, err)
+	}
+}
+
+// validate applies the admin content input rules, which rows bypass since
+// they are not bound through the request
+func (record CatalogRecord) validate() (domain.ContentSnapshot, error) {
+	switch {
+	case record.ExternalID == "":
+		return domain.ContentSnapshot{}, errors.New("external_id is required")
+	case utf8.RuneCountInString(record.ExternalID) > 100:
+		return domain.ContentSnapshot{}, errors.New("external_id must be at most 100 characters")
+	case strings.TrimSpace(record.Title) == "":
+		return domain.ContentSnapshot{}, errors.New("title is required")
+	case record.AccessLevel != domain.AccessLevelFree && record.AccessLevel != domain.AccessLevelBasic && record.AccessLevel != domain.AccessLevelPremium:
+		return domain.ContentSnapshot{}, errors.New("access_level must be one of free, basic, premium")
+	case record.ContentType != "" && !record.ContentType.IsValid():
+		return domain.ContentSnapshot{}, errors.New("content_type must be one of movie, episode, short")
+	case record.DurationSeconds <= 0:
+		return domain.ContentSnapshot{}, errors.New("duration_seconds must be positive")
+	case utf8.RuneCountInString(record.Genre) > 50:
+		return domain.ContentSnapshot{}, errors.New("genre must be at most 50 characters")
+	}
+	return record.snapshot()
+}
+
+// ParseCatalog reads an import file. CSV rows are numbered after the header,
+// JSON Lines rows by line with blank lines skipped.
+func ParseCatalog(format domain.CatalogFormat, data []byte) ([]CatalogRow, error) {
+	var rows []CatalogRow
+	var err error
+	switch format {
+	case domain.CatalogFormatCSV:
+		rows, err = parseCatalogCSV(data)
+	case domain.CatalogFormatJSONL:
+		rows, err = parseCatalogJSONL(data)
+	default:
+		return nil, domain.ErrInvalidCatalogFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrInvalidImportFile
+	}
+	if len(rows) > MaxCatalogImportRows {
+		return nil, domain.ErrFileTooLarge
+	}
+	return rows, nil
+}
+
+func parseCatalogCSV(data []byte) ([]CatalogRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, dup := columns[name]; dup || (name != "id" && !isCatalogColumn(name)) {
+			return nil, domain.ErrInvalidImportFile
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredCatalogColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, domain.ErrInvalidImportFile
+		}
+	}
+
+	var rows []CatalogRow
+	for number := 1; ; number++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, domain.ErrInvalidImportFile
+		}
+		row := CatalogRow{Number: number}
+		if err != nil {
+			row.Err = errors.New("row has a different number of fields than the header")
+		} else {
+			row.Record, row.Err = catalogRecordFromCSV(columns, fields)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func isCatalogColumn(name string) bool {
+	for _, column := range catalogColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func catalogRecordFromCSV(columns map[string]int, fields []string) (CatalogRecord, error) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	parseTime := func(name string) (*time.Time, error) {
+		raw := value(name)
+		if raw == "" {
+			return nil, nil
+		}
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+		}
+		return &at, nil
+	}
+	splitCountries := func(name string) []string {
+		raw := value(name)
+		if raw == "" {
+			return nil
+		}
+		return strings.Split(raw, ",")
+	}
+
+	record := CatalogRecord{ExternalID: value("external_id")}
+	record.Title = value("title")
+	record.Description = value("description")
+	record.AccessLevel = domain.AccessLevel(strings.ToLower(value("access_level")))
+	record.ContentType = domain.ContentType(strings.ToLower(value("content_type")))
+	record.Genre = value("genre")
+	record.ThumbnailURL = value("thumbnail_url")
+	record.TrailerURL = value("trailer_url")
+	record.VideoURL = value("video_url")
+	record.AllowedCountries = splitCountries("allowed_countries")
+	record.BlockedCountries = splitCountries("blocked_countries")
+
+	var err error
+	if record.DurationSeconds, err = strconv.Atoi(value("duration_seconds")); err != nil {
+		return record, errors.New("duration_seconds must be a whole number")
+	}
+	if raw := value("published"); raw != "" {
+		if record.Published, err = strconv.ParseBool(raw); err != nil {
+			return record, errors.New("published must be true or false")
+		}
+	}
+	if record.AvailableFrom, err = parseTime("available_from"); err != nil {
+		return record, err
+	}
+	if record.AvailableUntil, err = parseTime("available_until"); err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+func parseCatalogJSONL(data []byte) ([]CatalogRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	var rows []CatalogRow
+	for number := 1; scanner.Scan(); number++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row := CatalogRow{Number: number}
+		if err := json.Unmarshal(line, &row.Record); err != nil {
+			row.Err = errors.New("row is not a valid JSON object")
+		}
+		rows = append(rows, row)
+	}
+	if scanner.Err() != nil {
+		return nil, domain.ErrInvalidImportFile
+	}
+	return rows, nil
+}
+
+// ExportCatalog writes every title that is not in the trash, oldest first, in
+// the layout imports accept
+func (uc *CatalogUseCase) ExportCatalog(ctx context.Context, format domain.CatalogFormat, w io.Writer) error {
+	var write func(catalogExportRecord) error
+	var flush func() error
+	switch format {
+	case domain.CatalogFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(append([]string{"id"}, catalogColumns...)); err != nil {
+			return err
+		}
+		write = func(record catalogExportRecord) error { return writer.Write(record.csvFields()) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case domain.CatalogFormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(record catalogExportRecord) error { return encoder.Encode(record) }
+		flush = func() error { return nil }
+	default:
+		return domain.ErrInvalidCatalogFormat
+	}
+
+	keyset := contentKeyset(repositories.SortCreatedAt)
+	page := repositories.Page{Sort: repositories.SortCreatedAt, Limit: catalogExportBatch}
+	for {
+		contents, _, err := uc.contentRepo.List(ctx, repositories.ContentFilter{}, repositories.ContentScope{}, page)
+		if err != nil {
+			return err
+		}
+		for _, content := range contents {
+			if err := write(newCatalogExportRecord(content)); err != nil {
+				return err
+			}
+		}
+		if len(contents) < page.Limit {
+			return flush()
+		}
+		after := keyset(contents[len(contents)-1])
+		page.After = &after
+	}
+}
+
+func newCatalogExportRecord(content *domain.Content) catalogExportRecord {
+	snapshot := content.Snapshot()
+	record := catalogExportRecord{ID: content.ID}
+	if content.ExternalID != nil {
+		record.ExternalID = *content.ExternalID
+	}
+	record.CreateContentInput = CreateContentInput{
+		Title:            snapshot.Title,
+		Description:      snapshot.Description,
+		AccessLevel:      snapshot.AccessLevel,
+		ContentType:      snapshot.ContentType,
+		Genre:            snapshot.Genre,
+		DurationSeconds:  snapshot.DurationSeconds,
+		ThumbnailURL:     snapshot.ThumbnailURL,
+		TrailerURL:       snapshot.TrailerURL,
+		VideoURL:         snapshot.VideoURL,
+		Published:        snapshot.Published,
+		AvailableFrom:    snapshot.AvailableFrom,
+		AvailableUntil:   snapshot.AvailableUntil,
+		AllowedCountries: snapshot.AllowedCountries,
+		BlockedCountries: snapshot.BlockedCountries,
+	}
+	return record
+}
+
+func (record catalogExportRecord) csvFields() []string {
+	formatTime := func(at *time.Time) string {
+		if at == nil {
+			return ""
+		}
+		return at.UTC().Format(time.RFC3339Nano)
+	}
+	return []string{
+		record.ID.String(),
+		record.ExternalID,
+		record.Title,
+		record.Description,
+		string(record.AccessLevel),
+		string(record.ContentType),
+		record.Genre,
+		strconv.Itoa(record.DurationSeconds),
+		record.ThumbnailURL,
+		record.TrailerURL,
+		record.VideoURL,
+		strconv.FormatBool(record.Published),
+		formatTime(record.AvailableFrom),
+		formatTime(record.AvailableUntil),
+		strings.Join(record.AllowedCountries, ","),
+		strings.Join(record.BlockedCountries, ","),
+	}
+}
@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type CollectionUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	contentRepo    repositories.ContentRepository
+}
+
+func NewCollectionUseCase(collectionRepo repositories.CollectionRepository, contentRepo repositories.ContentRepository) *CollectionUseCase {
+	return &CollectionUseCase{collectionRepo: collectionRepo, contentRepo: contentRepo}
+}
+
+type CollectionInput struct {
+	Title        string             `json:"title" binding:"required"`
+	Description  string             `json:"description"`
+	AccessLevel  domain.AccessLevel `json:"access_level" binding:"omitempty,oneof=free basic premium"`
+	Position     int                `json:"position"`
+	VisibleFrom  *time.Time         `json:"visible_from"`
+	VisibleUntil *time.Time         `json:"visible_until"`
+	ContentIDs   []uuid.UUID        `json:"content_ids"`
+}
+
+type SetCollectionItemsInput struct {
+	ContentIDs []uuid.UUID `json:"content_ids" binding:"required"`
+}
+
+func (input CollectionInput) validateVisibility() error {
+	if input.VisibleFrom != nil && input.VisibleUntil != nil && !input.VisibleUntil.After(*input.VisibleFrom) {
+		return domain.ErrInvalidVisibility
+	}
+	return nil
+}
+
+func (input CollectionInput) apply(collection *domain.Collection) {
+	collection.Title = input.Title
+	collection.Description = input.Description
+	collection.AccessLevel = input.AccessLevel
+	if collection.AccessLevel == "" {
+		collection.AccessLevel = domain.AccessLevelFree
+	}
+	collection.Position = input.Position
+	collection.VisibleFrom = input.VisibleFrom
+	collection.VisibleUntil = input.VisibleUntil
+}
+
+func (uc *CollectionUseCase) CreateCollection(ctx context.Context, input CollectionInput) (*domain.Collection, error) {
+	if err := input.validateVisibility(); err != nil {
+		return nil, err
+	}
+	if err := uc.validateItems(ctx, input.ContentIDs); err != nil {
+		return nil, err
+	}
+	collection := &domain.Collection{ID: uuid.New()}
+	input.apply(collection)
+	if err := uc.collectionRepo.Create(ctx, collection); err != nil {
+		return nil, err
+	}
+	if err := uc.collectionRepo.SetItems(ctx, collection.ID, input.ContentIDs); err != nil {
+		return nil, err
+	}
+	return uc.collectionRepo.GetByID(ctx, collection.ID)
+}
+
+// UpdateCollection replaces the collection settings, items are only replaced
+// when content_ids is sent so metadata edits keep the curated order
+func (uc *CollectionUseCase) UpdateCollection(ctx context.Context, collectionID uuid.UUID, input CollectionInput) (*domain.Collection, error) {
+	if err := input.validateVisibility(); err != nil {
+		return nil, err
+	}
+	collection, err := uc.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	input.apply(collection)
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, err
+	}
+	if input.ContentIDs != nil {
+		if err := uc.SetItems(ctx, collectionID, SetCollectionItemsInput{ContentIDs: input.ContentIDs}); err != nil {
+			return nil, err
+		}
+	}
+	return uc.collectionRepo.GetByID(ctx, collectionID)
+}
+
+func (uc *CollectionUseCase) SetItems(ctx context.Context, collectionID uuid.UUID, input SetCollectionItemsInput) error {
+	if _, err := uc.collectionRepo.GetByID(ctx, collectionID); err != nil {
+		return err
+	}
+	if err := uc.validateItems(ctx, input.ContentIDs); err != nil {
+		return err
+	}
+	return uc.collectionRepo.SetItems(ctx, collectionID, input.ContentIDs)
+}
+
+func (uc *CollectionUseCase) GetCollection(ctx context.Context, collectionID uuid.UUID) (*domain.Collection, error) {
+	return uc.collectionRepo.GetByID(ctx, collectionID)
+}
+
+func (uc *CollectionUseCase) ListCollections(ctx context.Context) ([]*domain.Collection, error) {
+	return uc.collectionRepo.List(ctx)
+}
+
+func (uc *CollectionUseCase) DeleteCollection(ctx context.Context, collectionID uuid.UUID) error {
+	return uc.collectionRepo.Delete(ctx, collectionID)
+}
+
+func (uc *CollectionUseCase) validateItems(ctx context.Context, contentIDs []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(contentIDs))
+	for _, contentID := range contentIDs {
+		if seen[contentID] {
+			return domain.ErrInvalidInput
+		}
+		seen[contentID] = true
+		if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
@@ -13,42 +14,97 @@ type ContentUseCase struct {
 	contentRepo      repositories.ContentRepository
 	subscriptionRepo repositories.SubscriptionRepository
 	userRepo         repositories.UserRepository
+	revisionRepo     repositories.ContentRevisionRepository
 }
 
-func NewContentUseCase(contentRepo repositories.ContentRepository, subscriptionRepo repositories.SubscriptionRepository, userRepo repositories.UserRepository) *ContentUseCase {
-	return &ContentUseCase{contentRepo: contentRepo, subscriptionRepo: subscriptionRepo, userRepo: userRepo}
+func NewContentUseCase(contentRepo repositories.ContentRepository, subscriptionRepo repositories.SubscriptionRepository, userRepo repositories.UserRepository, revisionRepo repositories.ContentRevisionRepository) *ContentUseCase {
+	return &ContentUseCase{contentRepo: contentRepo, subscriptionRepo: subscriptionRepo, userRepo: userRepo, revisionRepo: revisionRepo}
 }
 
 type CreateContentInput struct {
-	Title           string             `json:"title" binding:"required"`
-	Description     string             `json:"description"`
-	AccessLevel     domain.AccessLevel `json:"access_level" binding:"required,oneof=free basic premium"`
-	DurationSeconds int                `json:"duration_seconds" binding:"required"`
-	ThumbnailURL    string             `json:"thumbnail_url"`
-	TrailerURL      string             `json:"trailer_url"`
-	VideoURL        string             `json:"video_url"`
-	Published       bool               `json:"published"`
-}
-
-func (uc *ContentUseCase) CreateContent(ctx context.Context, input CreateContentInput) (*domain.Content, error) {
-	content := &domain.Content{
-		ID:              uuid.New(),
-		Title:           input.Title,
-		Description:     input.Description,
-		AccessLevel:     input.AccessLevel,
-		DurationSeconds: input.DurationSeconds,
-		ThumbnailURL:    input.ThumbnailURL,
-		TrailerURL:      input.TrailerURL,
-		VideoURL:        input.VideoURL,
-		Published:       input.Published,
-	}
-	if err := uc.contentRepo.Create(ctx, content); err != nil {
+	Title            string             `json:"title" binding:"required"`
+	Description      string             `json:"description"`
+	AccessLevel      domain.AccessLevel `json:"access_level" binding:"required,oneof=free basic premium"`
+	ContentType      domain.ContentType `json:"content_type" binding:"omitempty,oneof=movie episode short"`
+	Genre            string             `json:"genre" binding:"max=50"`
+	DurationSeconds  int                `json:"duration_seconds" binding:"required"`
+	ThumbnailURL     string             `json:"thumbnail_url"`
+	TrailerURL       string             `json:"trailer_url"`
+	VideoURL         string             `json:"video_url"`
+	Published        bool               `json:"published"`
+	AvailableFrom    *time.Time         `json:"available_from"`
+	AvailableUntil   *time.Time         `json:"available_until"`
+	AllowedCountries []string           `json:"allowed_countries"`
+	BlockedCountries []string           `json:"blocked_countries"`
+}
+
+type UpdateTerritoriesInput struct {
+	ContentIDs       []uuid.UUID `json:"content_ids" binding:"required,min=1"`
+	AllowedCountries []string    `json:"allowed_countries"`
+	BlockedCountries []string    `json:"blocked_countries"`
+}
+
+func (input CreateContentInput) validateAvailability() error {
+	if input.AvailableFrom != nil && input.AvailableUntil != nil && !input.AvailableUntil.After(*input.AvailableFrom) {
+		return domain.ErrInvalidAvailability
+	}
+	return nil
+}
+
+// snapshot validates the input and converts it into revision fields
+func (input CreateContentInput) snapshot() (domain.ContentSnapshot, error) {
+	if err := input.validateAvailability(); err != nil {
+		return domain.ContentSnapshot{}, err
+	}
+	allowed, blocked, err := normalizeTerritories(input.AllowedCountries, input.BlockedCountries)
+	if err != nil {
+		return domain.ContentSnapshot{}, err
+	}
+	return domain.ContentSnapshot{
+		Title:            input.Title,
+		Description:      input.Description,
+		AccessLevel:      input.AccessLevel,
+		ContentType:      input.ContentType,
+		Genre:            domain.NormalizeGenre(input.Genre),
+		DurationSeconds:  input.DurationSeconds,
+		ThumbnailURL:     input.ThumbnailURL,
+		TrailerURL:       input.TrailerURL,
+		VideoURL:         input.VideoURL,
+		Published:        input.Published,
+		AvailableFrom:    input.AvailableFrom,
+		AvailableUntil:   input.AvailableUntil,
+		AllowedCountries: allowed,
+		BlockedCountries: blocked,
+	}.Normalize(), nil
+}
+
+// CreateContent stores the content together with its first revision
+func (uc *ContentUseCase) CreateContent(ctx context.Context, authorID uuid.UUID, input CreateContentInput) (*domain.Content, error) {
+	snapshot, err := input.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return uc.createContent(ctx, authorID, snapshot, nil)
+}
+
+func (uc *ContentUseCase) createContent(ctx context.Context, authorID uuid.UUID, snapshot domain.ContentSnapshot, externalID *string) (*domain.Content, error) {
+	content := &domain.Content{ID: uuid.New(), ExternalID: externalID}
+	content.ApplySnapshot(snapshot)
+	revision := &domain.ContentRevision{
+		ID:        uuid.New(),
+		ContentID: content.ID,
+		Status:    domain.RevisionStatusPublished,
+		AuthorID:  authorID,
+		Snapshot:  snapshot,
+		Changes:   domain.ContentSnapshot{}.Diff(snapshot),
+	}
+	if err := uc.revisionRepo.Record(ctx, content, revision, false); err != nil {
 		return nil, err
 	}
 	return content, nil
 }
 
-func (uc *ContentUseCase) GetContent(ctx context.Context, contentID uuid.UUID, userID *uuid.UUID) (*domain.Content, error) {
+func (uc *ContentUseCase) GetContent(ctx context.Context, contentID uuid.UUID, userID *uuid.UUID, country string) (*domain.Content, error) {
 	content, err := uc.contentRepo.GetByID(ctx, contentID)
 	if err != nil {
 		return nil, err
@@ -56,6 +112,12 @@ func (uc *ContentUseCase) GetContent(ctx context.Context, contentID uuid.UUID, u
 	if !content.Published {
 		return nil, domain.ErrContentNotPublished
 	}
+	if !content.IsWithinAvailabilityWindow(time.Now()) {
+		return nil, domain.ErrContentNotAvailable
+	}
+	if !content.IsAvailableInCountry(country) {
+		return nil, domain.ErrContentNotAvailableInRegion
+	}
 	if userID != nil {
 		hasAccess, err := uc.CheckAccess(ctx, *userID, content.AccessLevel)
 		if err != nil {
@@ -72,27 +134,186 @@ func (uc *ContentUseCase) GetContent(ctx context.Context, contentID uuid.UUID, u
 	return content, nil
 }
 
-func (uc *ContentUseCase) ListContent(ctx context.Context, publishedOnly bool, limit, offset int) ([]*domain.Content, int64, error) {
-	filters := make(map[string]interface{})
-	if publishedOnly {
-		filters["published"] = true
+func (uc *ContentUseCase) ListContent(ctx context.Context, filter repositories.ContentFilter, country string, page repositories.Page) ([]*domain.Content, int64, *repositories.Keyset, error) {
+	page, err := normalizePage(page, ContentSortKeys)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	contents, total, err := uc.contentRepo.List(ctx, filter, viewerScope(filter.PublishedOnly(), country), lookahead(page))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	contents, next := trimPage(contents, page.Limit, contentKeyset(page.Sort))
+	return contents, total, next, nil
+}
+
+// ListLeavingSoon lists titles viewers can watch now whose availability ends
+// within the given duration. Titles that have not premiered yet are left out.
+func (uc *ContentUseCase) ListLeavingSoon(ctx context.Context, country string, within time.Duration) ([]*domain.Content, error) {
+	now := time.Now()
+	contents, err := uc.contentRepo.ListAvailableUntilBetween(ctx, now, now.Add(within))
+	if err != nil {
+		return nil, err
+	}
+	leaving := make([]*domain.Content, 0, len(contents))
+	for _, content := range contents {
+		if content.IsWithinAvailabilityWindow(now) && content.IsAvailableInCountry(country) {
+			leaving = append(leaving, content)
+		}
+	}
+	return leaving, nil
+}
+
+func (uc *ContentUseCase) ListContentByPerson(ctx context.Context, personName string, filter repositories.ContentFilter, country string, page repositories.Page) ([]*domain.Content, int64, *repositories.Keyset, error) {
+	page, err := normalizePage(page, ContentSortKeys)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	contents, total, err := uc.contentRepo.ListByPersonName(ctx, personName, filter, viewerScope(filter.PublishedOnly(), country), lookahead(page))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	contents, next := trimPage(contents, page.Limit, contentKeyset(page.Sort))
+	return contents, total, next, nil
+}
+
+// UpdateTerritories replaces the licensed territories of each content that
+// exists, recording a revision for every content that changes. Returns how
+// many of the contents were found.
+func (uc *ContentUseCase) UpdateTerritories(ctx context.Context, authorID uuid.UUID, input UpdateTerritoriesInput) (int64, error) {
+	allowed, blocked, err := normalizeTerritories(input.AllowedCountries, input.BlockedCountries)
+	if err != nil {
+		return 0, err
+	}
+	var updated int64
+	for _, contentID := range input.ContentIDs {
+		content, err := uc.contentRepo.GetByID(ctx, contentID)
+		if err == domain.ErrContentNotFound {
+			continue
+		}
+		if err != nil {
+			return updated, err
+		}
+		snapshot := content.Snapshot()
+		snapshot.AllowedCountries = allowed
+		snapshot.BlockedCountries = blocked
+		if _, err := uc.publishSnapshot(ctx, authorID, content, snapshot, nil, false); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// UpdateMarkers replaces the intro, credits and chapter markers of a content
+// as a new revision
+func (uc *ContentUseCase) UpdateMarkers(ctx context.Context, authorID, contentID uuid.UUID, markers domain.ContentMarkers) (*domain.Content, error) {
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := markers.Validate(content.DurationSeconds); err != nil {
+		return nil, err
 	}
-	return uc.contentRepo.List(ctx, filters, limit, offset)
+	if markers.Chapters == nil {
+		markers.Chapters = domain.Chapters{}
+	}
+	snapshot := content.Snapshot()
+	snapshot.Markers = &markers
+	return uc.publishSnapshot(ctx, authorID, content, snapshot, nil, false)
+}
+
+// UpdateContent applies the input to the live content and records the changed
+// fields as a new revision, an input that changes nothing records none
+func (uc *ContentUseCase) UpdateContent(ctx context.Context, authorID, contentID uuid.UUID, input CreateContentInput) (*domain.Content, error) {
+	snapshot, err := input.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.publishSnapshot(ctx, authorID, content, snapshot, nil, false)
+}
+
+// SaveDraft stores the input as the content's draft without touching the live
+// version, the draft's changes are relative to the live version
+func (uc *ContentUseCase) SaveDraft(ctx context.Context, authorID, contentID uuid.UUID, input CreateContentInput) (*domain.ContentRevision, error) {
+	snapshot, err := input.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	draft := &domain.ContentRevision{
+		ID:        uuid.New(),
+		ContentID: contentID,
+		Status:    domain.RevisionStatusDraft,
+		AuthorID:  authorID,
+		Snapshot:  snapshot,
+		Changes:   content.Snapshot().Diff(snapshot),
+	}
+	if err := uc.revisionRepo.SaveDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (uc *ContentUseCase) DiscardDraft(ctx context.Context, contentID uuid.UUID) error {
+	return uc.revisionRepo.DeleteDraft(ctx, contentID)
+}
+
+func (uc *ContentUseCase) ListRevisions(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentRevision, error) {
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return uc.revisionRepo.ListByContentID(ctx, contentID)
 }
 
-func (uc *ContentUseCase) UpdateContent(ctx context.Context, contentID uuid.UUID, input CreateContentInput) (*domain.Content, error) {
+// RestoreRevision makes an earlier revision live again as a new revision.
+// Restoring the draft publishes it and closes the draft.
+func (uc *ContentUseCase) RestoreRevision(ctx context.Context, authorID, contentID uuid.UUID, number int) (*domain.Content, error) {
+	revision, err := uc.revisionRepo.GetByNumber(ctx, contentID, number)
+	if err != nil {
+		return nil, err
+	}
 	content, err := uc.contentRepo.GetByID(ctx, contentID)
 	if err != nil {
 		return nil, err
 	}
-	content.Title = input.Title
-	content.Description = input.Description
-	content.AccessLevel = input.AccessLevel
-	content.DurationSeconds = input.DurationSeconds
-	content.ThumbnailURL = input.ThumbnailURL
-	content.VideoURL = input.VideoURL
-	content.Published = input.Published
-	if err := uc.contentRepo.Update(ctx, content); err != nil {
+	return uc.publishSnapshot(ctx, authorID, content, revision.Snapshot, &number, revision.Status == domain.RevisionStatusDraft)
+}
+
+func (uc *ContentUseCase) publishSnapshot(ctx context.Context, authorID uuid.UUID, content *domain.Content, snapshot domain.ContentSnapshot, restoredFrom *int, fromDraft bool) (*domain.Content, error) {
+	changes := content.Snapshot().Diff(snapshot)
+	if len(changes) == 0 {
+		if fromDraft {
+			if err := uc.revisionRepo.DeleteDraft(ctx, content.ID); err != nil {
+				return nil, err
+			}
+		}
+		return content, nil
+	}
+	content.ApplySnapshot(snapshot)
+	return uc.recordRevision(ctx, authorID, content, changes, restoredFrom, fromDraft)
+}
+
+// recordRevision saves the already edited content with a revision of its new
+// snapshot, so every change of a snapshot field can be traced and rolled back
+func (uc *ContentUseCase) recordRevision(ctx context.Context, authorID uuid.UUID, content *domain.Content, changes domain.RevisionChanges, restoredFrom *int, discardDraft bool) (*domain.Content, error) {
+	revision := &domain.ContentRevision{
+		ID:           uuid.New(),
+		ContentID:    content.ID,
+		Status:       domain.RevisionStatusPublished,
+		AuthorID:     authorID,
+		Snapshot:     content.Snapshot(),
+		Changes:      changes,
+		RestoredFrom: restoredFrom,
+	}
+	if err := uc.revisionRepo.Record(ctx, content, revision, discardDraft); err != nil {
 		return nil, err
 	}
 	return content, nil
@@ -102,6 +323,17 @@ func (uc *ContentUseCase) DeleteContent(ctx context.Context, contentID uuid.UUID
 	return uc.contentRepo.Delete(ctx, contentID)
 }
 
+func (uc *ContentUseCase) ListDeletedContent(ctx context.Context) ([]*domain.Content, error) {
+	return uc.contentRepo.ListDeleted(ctx)
+}
+
+func (uc *ContentUseCase) RestoreContent(ctx context.Context, contentID uuid.UUID) (*domain.Content, error) {
+	if err := uc.contentRepo.Restore(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return uc.contentRepo.GetByID(ctx, contentID)
+}
+
 func (uc *ContentUseCase) CheckAccess(ctx context.Context, userID uuid.UUID, requiredLevel domain.AccessLevel) (bool, error) {
 	if requiredLevel == domain.AccessLevelFree {
 		return true, nil
@@ -125,3 +357,26 @@ func (uc *ContentUseCase) CheckAccess(ctx context.Context, userID uuid.UUID, req
 	}
 	return false, nil
 }
+
+// viewerScope limits published listings to titles currently watchable from the viewer's country
+func viewerScope(publishedOnly bool, country string) repositories.ContentScope {
+	var scope repositories.ContentScope
+	if publishedOnly {
+		now := time.Now()
+		scope.AvailableAt = &now
+		scope.Country = &country
+	}
+	return scope
+}
+
+func normalizeTerritories(allowed, blocked []string) (domain.CountryCodes, domain.CountryCodes, error) {
+	allowedCodes, err := domain.NormalizeCountryCodes(allowed)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockedCodes, err := domain.NormalizeCountryCodes(blocked)
+	if err != nil {
+		return nil, nil, err
+	}
+	return allowedCodes, blockedCodes, nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type HomeRailType string
+
+const (
+	HomeRailCollection       HomeRailType = "collection"
+	HomeRailContinueWatching HomeRailType = "continue_watching"
+	HomeRailTrending         HomeRailType = "trending"
+	HomeRailGenre            HomeRailType = "genre"
+)
+
+const (
+	homeRailSize   = 20
+	homeGenreRails = 4
+)
+
+type HomeRailItem struct {
+	Content  *domain.Content
+	Progress *WatchProgress
+}
+
+type HomeRail struct {
+	ID    string
+	Type  HomeRailType
+	Title string
+	Items []*HomeRailItem
+}
+
+type HomeUseCase struct {
+	collectionRepo   repositories.CollectionRepository
+	contentRepo      repositories.ContentRepository
+	watchHistoryRepo repositories.WatchHistoryRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	trendingUseCase  *TrendingUseCase
+}
+
+func NewHomeUseCase(collectionRepo repositories.CollectionRepository, contentRepo repositories.ContentRepository, watchHistoryRepo repositories.WatchHistoryRepository, subscriptionRepo repositories.SubscriptionRepository, trendingUseCase *TrendingUseCase) *HomeUseCase {
+	return &HomeUseCase{
+		collectionRepo:   collectionRepo,
+		contentRepo:      contentRepo,
+		watchHistoryRepo: watchHistoryRepo,
+		subscriptionRepo: subscriptionRepo,
+		trendingUseCase:  trendingUseCase,
+	}
+}
+
+// GetHome assembles the home screen in display order: continue watching for
+// signed in users, curated collections, this week's trending titles and then
+// rows for the biggest genres. Collections only reach viewers whose plan covers
+// their target access level, and rails left empty after filtering are dropped.
+func (uc *HomeUseCase) GetHome(ctx context.Context, userID *uuid.UUID, country string) ([]*HomeRail, error) {
+	level, err := userAccessLevel(ctx, uc.subscriptionRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	viewable := func(content *domain.Content) bool {
+		return content != nil && content.IsAvailableAt(now) && content.IsAvailableInCountry(country)
+	}
+	var rails []*HomeRail
+
+	if userID != nil {
+		histories, err := uc.watchHistoryRepo.GetContinueWatching(ctx, *userID, homeRailSize)
+		if err != nil {
+			return nil, err
+		}
+		rail := &HomeRail{ID: string(HomeRailContinueWatching), Type: HomeRailContinueWatching, Title: "Continue Watching"}
+		for _, history := range histories {
+			if viewable(history.Content) {
+				rail.Items = append(rail.Items, &HomeRailItem{Content: history.Content, Progress: newWatchProgress(history)})
+			}
+		}
+		rails = appendRail(rails, rail)
+	}
+
+	collections, err := uc.collectionRepo.ListVisible(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		if !level.Allows(collection.AccessLevel) {
+			continue
+		}
+		rail := &HomeRail{ID: collection.ID.String(), Type: HomeRailCollection, Title: collection.Title}
+		for _, item := range collection.Items {
+			if viewable(item.Content) && len(rail.Items) < homeRailSize {
+				rail.Items = append(rail.Items, &HomeRailItem{Content: item.Content})
+			}
+		}
+		rails = appendRail(rails, rail)
+	}
+
+	trending, err := uc.trendingUseCase.GetTrending(ctx, domain.TrendingWindowWeek, country, 10)
+	if err != nil {
+		return nil, err
+	}
+	trendingRail := &HomeRail{ID: string(HomeRailTrending), Type: HomeRailTrending, Title: "Top 10 This Week"}
+	for _, entry := range trending {
+		if viewable(entry.Content) {
+			trendingRail.Items = append(trendingRail.Items, &HomeRailItem{Content: entry.Content})
+		}
+	}
+	rails = appendRail(rails, trendingRail)
+
+	scope := viewerScope(true, country)
+	published := true
+	genres, err := uc.contentRepo.ListGenres(ctx, scope, homeGenreRails)
+	if err != nil {
+		return nil, err
+	}
+	for _, genre := range genres {
+		filter := repositories.ContentFilter{Published: &published, Genre: genre}
+		contents, _, err := uc.contentRepo.List(ctx, filter, scope, repositories.Page{
+			Sort: repositories.SortCreatedAt, Desc: true, Limit: homeRailSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rail := &HomeRail{ID: string(HomeRailGenre) + ":" + genre, Type: HomeRailGenre, Title: genreTitle(genre)}
+		for _, content := range contents {
+			rail.Items = append(rail.Items, &HomeRailItem{Content: content})
+		}
+		rails = appendRail(rails, rail)
+	}
+	return rails, nil
+}
+
+func appendRail(rails []*HomeRail, rail *HomeRail) []*HomeRail {
+	if len(rail.Items) == 0 {
+		return rails
+	}
+	return append(rails, rail)
+}
+
+// genreTitle capitalises each word of a normalized genre, "sci fi" becomes "Sci Fi"
+func genreTitle(genre string) string {
+	words := strings.Fields(genre)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type LocalizationUseCase struct {
+	translationRepo repositories.TranslationRepository
+	contentRepo     repositories.ContentRepository
+	planRepo        repositories.PlanRepository
+	userRepo        repositories.UserRepository
+	defaultLocale   string
+}
+
+func NewLocalizationUseCase(translationRepo repositories.TranslationRepository, contentRepo repositories.ContentRepository, planRepo repositories.PlanRepository, userRepo repositories.UserRepository, defaultLocale string) *LocalizationUseCase {
+	if normalized, err := domain.NormalizeLanguageTag(defaultLocale); err == nil {
+		defaultLocale = normalized
+	}
+	return &LocalizationUseCase{
+		translationRepo: translationRepo,
+		contentRepo:     contentRepo,
+		planRepo:        planRepo,
+		userRepo:        userRepo,
+		defaultLocale:   defaultLocale,
+	}
+}
+
+type ContentTranslationInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type PlanTranslationInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Locales builds the fallback chain for a request. A signed in user's saved
+// preference wins over the Accept-Language tags their client sent.
+func (uc *LocalizationUseCase) Locales(ctx context.Context, userID *uuid.UUID, requested []string) ([]string, error) {
+	preferred := requested
+	if userID != nil {
+		user, err := uc.userRepo.GetByID(ctx, *userID)
+		if err != nil && err != domain.ErrUserNotFound {
+			return nil, err
+		}
+		if user != nil && user.PreferredLocale != "" {
+			preferred = append([]string{user.PreferredLocale}, requested...)
+		}
+	}
+	return domain.LocaleChain(preferred, uc.defaultLocale), nil
+}
+
+// LocalizeContents swaps title and description for the first translation in
+// the chain that has them, each field falling back on its own
+func (uc *LocalizationUseCase) LocalizeContents(ctx context.Context, userID *uuid.UUID, requested []string, contents ...*domain.Content) error {
+	chain, err := uc.Locales(ctx, userID, requested)
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, 0, len(contents))
+	for _, content := range contents {
+		content.Locale = uc.defaultLocale
+		ids = append(ids, content.ID)
+	}
+	lookup := chain[:len(chain)-1]
+	if len(lookup) == 0 || len(ids) == 0 {
+		return nil
+	}
+	translations, err := uc.translationRepo.ListContentTranslations(ctx, ids, lookup)
+	if err != nil {
+		return err
+	}
+	byContent := make(map[uuid.UUID]map[string]*domain.ContentTranslation)
+	for _, translation := range translations {
+		if byContent[translation.ContentID] == nil {
+			byContent[translation.ContentID] = make(map[string]*domain.ContentTranslation)
+		}
+		byContent[translation.ContentID][translation.Locale] = translation
+	}
+	for _, content := range contents {
+		found := byContent[content.ID]
+		if found == nil {
+			continue
+		}
+		if title, locale := firstTranslated(lookup, func(l string) string {
+			if t := found[l]; t != nil {
+				return t.Title
+			}
+			return ""
+		}); title != "" {
+			content.Title = title
+			content.Locale = locale
+		}
+		if description, _ := firstTranslated(lookup, func(l string) string {
+			if t := found[l]; t != nil {
+				return t.Description
+			}
+			return ""
+		}); description != "" {
+			content.Description = description
+		}
+	}
+	return nil
+}
+
+// LocalizePlans applies the same fallback rules to plan names and descriptions
+func (uc *LocalizationUseCase) LocalizePlans(ctx context.Context, userID *uuid.UUID, requested []string, plans ...*domain.Plan) error {
+	chain, err := uc.Locales(ctx, userID, requested)
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, 0, len(plans))
+	for _, plan := range plans {
+		plan.Locale = uc.defaultLocale
+		ids = append(ids, plan.ID)
+	}
+	lookup := chain[:len(chain)-1]
+	if len(lookup) == 0 || len(ids) == 0 {
+		return nil
+	}
+	translations, err := uc.translationRepo.ListPlanTranslations(ctx, ids, lookup)
+	if err != nil {
+		return err
+	}
+	byPlan := make(map[uuid.UUID]map[string]*domain.PlanTranslation)
+	for _, translation := range translations {
+		if byPlan[translation.PlanID] == nil {
+			byPlan[translation.PlanID] = make(map[string]*domain.PlanTranslation)
+		}
+		byPlan[translation.PlanID][translation.Locale] = translation
+	}
+	for _, plan := range plans {
+		found := byPlan[plan.ID]
+		if found == nil {
+			continue
+		}
+		if name, locale := firstTranslated(lookup, func(l string) string {
+			if t := found[l]; t != nil {
+				return t.Name
+			}
+			return ""
+		}); name != "" {
+			plan.Name = name
+			plan.Locale = locale
+		}
+		if description, _ := firstTranslated(lookup, func(l string) string {
+			if t := found[l]; t != nil {
+				return t.Description
+			}
+			return ""
+		}); description != "" {
+			plan.Description = description
+		}
+	}
+	return nil
+}
+
+func firstTranslated(chain []string, field func(locale string) string) (string, string) {
+	for _, locale := range chain {
+		if value := field(locale); value != "" {
+			return value, locale
+		}
+	}
+	return "", ""
+}
+
+func (uc *LocalizationUseCase) translationLocale(locale string) (string, error) {
+	locale, err := domain.NormalizeLanguageTag(locale)
+	if err != nil {
+		return "", err
+	}
+	if locale == uc.defaultLocale {
+		return "", domain.ErrDefaultLocaleTranslation
+	}
+	return locale, nil
+}
+
+func (uc *LocalizationUseCase) ListContentTranslations(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentTranslation, error) {
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return uc.translationRepo.ListContentTranslations(ctx, []uuid.UUID{contentID}, nil)
+}
+
+func (uc *LocalizationUseCase) PutContentTranslation(ctx context.Context, contentID uuid.UUID, locale string, input ContentTranslationInput) (*domain.ContentTranslation, error) {
+	locale, err := uc.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if input.Title == "" && input.Description == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	translation := &domain.ContentTranslation{
+		ContentID:   contentID,
+		Locale:      locale,
+		Title:       input.Title,
+		Description: input.Description,
+	}
+	if err := uc.translationRepo.UpsertContentTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (uc *LocalizationUseCase) DeleteContentTranslation(ctx context.Context, contentID uuid.UUID, locale string) error {
+	locale, err := domain.NormalizeLanguageTag(locale)
+	if err != nil {
+		return err
+	}
+	return uc.translationRepo.DeleteContentTranslation(ctx, contentID, locale)
+}
+
+func (uc *LocalizationUseCase) ListPlanTranslations(ctx context.Context, planID uuid.UUID) ([]*domain.PlanTranslation, error) {
+	if _, err := uc.planRepo.GetByID(ctx, planID); err != nil {
+		return nil, err
+	}
+	return uc.translationRepo.ListPlanTranslations(ctx, []uuid.UUID{planID}, nil)
+}
+
+func (uc *LocalizationUseCase) PutPlanTranslation(ctx context.Context, planID uuid.UUID, locale string, input PlanTranslationInput) (*domain.PlanTranslation, error) {
+	locale, err := uc.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name == "" && input.Description == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := uc.planRepo.GetByID(ctx, planID); err != nil {
+		return nil, err
+	}
+	translation := &domain.PlanTranslation{
+		PlanID:      planID,
+		Locale:      locale,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	if err := uc.translationRepo.UpsertPlanTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (uc *LocalizationUseCase) DeletePlanTranslation(ctx context.Context, planID uuid.UUID, locale string) error {
+	locale, err := domain.NormalizeLanguageTag(locale)
+	if err != nil {
+		return err
+	}
+	return uc.translationRepo.DeletePlanTranslation(ctx, planID, locale)
+}
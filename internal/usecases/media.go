@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/imaging"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxArtworkBytes = 10 << 20
+	MaxAvatarBytes  = 2 << 20
+	// Decoding allocates by declared dimensions, not file size, so a small
+	// file can claim a huge canvas
+	MaxArtworkPixels = 40_000_000
+)
+
+// Types are sniffed from the file bytes, never trusted from the client
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// ArtworkVariantWidths are the downscaled widths generated for every decodable
+// artwork upload, WebP has no standard library decoder and keeps the original only
+var ArtworkVariantWidths = []int{320, 640, 1280}
+
+type MediaUseCase struct {
+	storage        infrastructure.ObjectStorage
+	contentUseCase *ContentUseCase
+	contentRepo    repositories.ContentRepository
+	userRepo       repositories.UserRepository
+}
+
+func NewMediaUseCase(storage infrastructure.ObjectStorage, contentUseCase *ContentUseCase, contentRepo repositories.ContentRepository, userRepo repositories.UserRepository) *MediaUseCase {
+	return &MediaUseCase{storage: storage, contentUseCase: contentUseCase, contentRepo: contentRepo, userRepo: userRepo}
+}
+
+// UploadArtwork stores the image as the content's thumbnail. A new thumbnail
+// is recorded as a revision like any other edit of the content.
+func (uc *MediaUseCase) UploadArtwork(ctx context.Context, authorID, contentID uuid.UUID, data []byte) (*domain.Content, error) {
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil && int64(config.Width)*int64(config.Height) > MaxArtworkPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+	url, mimeType, err := uc.storeImage(ctx, "artwork", data, MaxArtworkBytes)
+	if err != nil {
+		return nil, err
+	}
+	before := content.Snapshot()
+	content.ThumbnailURL = url
+	content.ThumbnailVariants, content.ThumbnailBlurHash, content.ThumbnailColor = nil, "", ""
+	if src, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+		variants, err := uc.storeVariants(ctx, src, mimeType, url)
+		if err != nil {
+			return nil, err
+		}
+		content.ThumbnailVariants = variants
+		content.ThumbnailColor = imaging.DominantColor(src)
+		if content.ThumbnailBlurHash, err = imaging.BlurHash(src, 4, 3); err != nil {
+			log.Printf("blurhash for content %s: %v", contentID, err)
+		}
+	}
+	if changes := before.Diff(content.Snapshot()); len(changes) > 0 {
+		return uc.contentUseCase.recordRevision(ctx, authorID, content, changes, nil, false)
+	}
+	if err := uc.contentRepo.Update(ctx, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (uc *MediaUseCase) UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	url, _, err := uc.storeImage(ctx, "avatars", data, MaxAvatarBytes)
+	if err != nil {
+		return nil, err
+	}
+	user.Picture = url
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// storeImage validates and stores data under a content-addressed key, so
+// re-uploads are idempotent and replaced objects are left for shared use
+func (uc *MediaUseCase) storeImage(ctx context.Context, prefix string, data []byte, maxBytes int) (string, string, error) {
+	if len(data) > maxBytes {
+		return "", "", domain.ErrFileTooLarge
+	}
+	mimeType := http.DetectContentType(data)
+	ext, ok := imageExtensions[mimeType]
+	if !ok {
+		return "", "", domain.ErrUnsupportedMediaType
+	}
+	key := contentAddressedKey(prefix, data, ext)
+	if err := uc.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), mimeType); err != nil {
+		return "", "", err
+	}
+	return uc.storage.URL(key), mimeType, nil
+}
+
+// storeVariants uploads a downscaled copy per width narrower than the source
+// and lists the original last, so clients can pick the smallest that fits.
+// PNGs stay PNG to keep transparency, everything else becomes JPEG.
+func (uc *MediaUseCase) storeVariants(ctx context.Context, src image.Image, mimeType, originalURL string) (domain.ImageVariants, error) {
+	bounds := src.Bounds()
+	variants := make(domain.ImageVariants, 0, len(ArtworkVariantWidths)+1)
+	for _, width := range ArtworkVariantWidths {
+		if width >= bounds.Dx() {
+			break
+		}
+		resized := imaging.Resize(src, width)
+		var buf bytes.Buffer
+		variantType, ext := "image/jpeg", ".jpg"
+		if mimeType == "image/png" {
+			variantType, ext = "image/png", ".png"
+			if err := png.Encode(&buf, resized); err != nil {
+				return nil, err
+			}
+		} else if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		key := contentAddressedKey("artwork", buf.Bytes(), ext)
+		if err := uc.storage.Put(ctx, key, bytes.NewReader(buf.Bytes()), int64(buf.Len()), variantType); err != nil {
+			return nil, err
+		}
+		variants = append(variants, domain.ImageVariant{
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+			URL:    uc.storage.URL(key),
+		})
+	}
+	return append(variants, domain.ImageVariant{Width: bounds.Dx(), Height: bounds.Dy(), URL: originalURL}), nil
+}
+
+// contentAddressedKey fans objects out by the first hash byte, e.g. artwork/ab/abcd...jpg
+func contentAddressedKey(prefix string, data []byte, ext string) string {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	return prefix + "/" + digest[:2] + "/" + digest + ext
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ContentSortKeys      = []repositories.SortKey{repositories.SortCreatedAt, repositories.SortTitle, repositories.SortDuration}
+	WatchHistorySortKeys = []repositories.SortKey{repositories.SortLastWatchedAt, repositories.SortCreatedAt}
+)
+
+// normalizePage rejects sorts the listing does not support and keeps the
+// limit between 1 and MaxPageSize
+func normalizePage(page repositories.Page, allowed []repositories.SortKey) (repositories.Page, error) {
+	supported := false
+	for _, key := range allowed {
+		if key == page.Sort {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return page, domain.ErrInvalidSort
+	}
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+	if page.Limit > MaxPageSize {
+		page.Limit = MaxPageSize
+	}
+	return page, nil
+}
+
+// lookahead asks for one row more than the page so the caller can tell
+// whether another page follows without a second query
+func lookahead(page repositories.Page) repositories.Page {
+	page.Limit++
+	return page
+}
+
+// trimPage drops the lookahead row and returns the keyset of the last row
+// kept, or nil when this was the final page
+func trimPage[T any](items []T, limit int, keyset func(T) repositories.Keyset) ([]T, *repositories.Keyset) {
+	if len(items) <= limit {
+		return items, nil
+	}
+	items = items[:limit]
+	next := keyset(items[limit-1])
+	return items, &next
+}
+
+func contentKeyset(sort repositories.SortKey) func(*domain.Content) repositories.Keyset {
+	return func(content *domain.Content) repositories.Keyset {
+		var value interface{}
+		switch sort {
+		case repositories.SortTitle:
+			value = content.Title
+		case repositories.SortDuration:
+			value = content.DurationSeconds
+		default:
+			value = content.CreatedAt
+		}
+		return repositories.Keyset{Value: sort.FormatValue(value), ID: content.ID}
+	}
+}
+
+func watchHistoryKeyset(sort repositories.SortKey) func(*domain.WatchHistory) repositories.Keyset {
+	return func(history *domain.WatchHistory) repositories.Keyset {
+		value := history.LastWatchedAt
+		if sort == repositories.SortCreatedAt {
+			value = history.CreatedAt
+		}
+		return repositories.Keyset{Value: sort.FormatValue(value), ID: history.ID}
+	}
+}
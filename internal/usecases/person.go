@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type PersonUseCase struct {
+	personRepo       repositories.PersonRepository
+	contentRepo      repositories.ContentRepository
+	subscriptionRepo repositories.SubscriptionRepository
+}
+
+func NewPersonUseCase(personRepo repositories.PersonRepository, contentRepo repositories.ContentRepository, subscriptionRepo repositories.SubscriptionRepository) *PersonUseCase {
+	return &PersonUseCase{
+		personRepo:       personRepo,
+		contentRepo:      contentRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+type CreatePersonInput struct {
+	Name      string     `json:"name" binding:"required"`
+	Bio       string     `json:"bio"`
+	PhotoURL  string     `json:"photo_url"`
+	BirthDate *time.Time `json:"birth_date"`
+}
+
+type AddCreditInput struct {
+	PersonID      uuid.UUID         `json:"person_id" binding:"required"`
+	Role          domain.PersonRole `json:"role" binding:"required,oneof=actor director writer"`
+	CharacterName string            `json:"character_name"`
+	BillingOrder  int               `json:"billing_order" binding:"gte=0"`
+}
+
+func (uc *PersonUseCase) CreatePerson(ctx context.Context, input CreatePersonInput) (*domain.Person, error) {
+	person := &domain.Person{
+		ID:        uuid.New(),
+		Name:      input.Name,
+		Bio:       input.Bio,
+		PhotoURL:  input.PhotoURL,
+		BirthDate: input.BirthDate,
+	}
+	if err := uc.personRepo.Create(ctx, person); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func (uc *PersonUseCase) GetPerson(ctx context.Context, personID uuid.UUID, userID *uuid.UUID, country string) (*domain.Person, []*domain.ContentCredit, error) {
+	person, err := uc.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+	level, err := userAccessLevel(ctx, uc.subscriptionRepo, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	credits, err := uc.personRepo.GetCreditsByPersonID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	filmography := make([]*domain.ContentCredit, 0, len(credits))
+	for _, credit := range credits {
+		if credit.Content == nil || !credit.Content.IsAvailableAt(now) || !credit.Content.IsAvailableInCountry(country) {
+			continue
+		}
+		if !level.Allows(credit.Content.AccessLevel) {
+			continue
+		}
+		filmography = append(filmography, credit)
+	}
+	return person, filmography, nil
+}
+
+func (uc *PersonUseCase) ListPeople(ctx context.Context, name string, limit, offset int) ([]*domain.Person, int64, error) {
+	return uc.personRepo.List(ctx, name, limit, offset)
+}
+
+func (uc *PersonUseCase) UpdatePerson(ctx context.Context, personID uuid.UUID, input CreatePersonInput) (*domain.Person, error) {
+	person, err := uc.personRepo.GetByID(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	person.Name = input.Name
+	person.Bio = input.Bio
+	person.PhotoURL = input.PhotoURL
+	person.BirthDate = input.BirthDate
+	if err := uc.personRepo.Update(ctx, person); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func (uc *PersonUseCase) DeletePerson(ctx context.Context, personID uuid.UUID) error {
+	return uc.personRepo.Delete(ctx, personID)
+}
+
+func (uc *PersonUseCase) GetContentCredits(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentCredit, error) {
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !content.Published {
+		return nil, domain.ErrContentNotPublished
+	}
+	if !content.IsWithinAvailabilityWindow(time.Now()) {
+		return nil, domain.ErrContentNotAvailable
+	}
+	return uc.personRepo.GetCreditsByContentID(ctx, contentID)
+}
+
+func (uc *PersonUseCase) AddCredit(ctx context.Context, contentID uuid.UUID, input AddCreditInput) (*domain.ContentCredit, error) {
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	person, err := uc.personRepo.GetByID(ctx, input.PersonID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uc.personRepo.GetCredit(ctx, contentID, input.PersonID, input.Role)
+	if err != nil && err != domain.ErrCreditNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domain.ErrCreditExists
+	}
+	credit := &domain.ContentCredit{
+		ID:            uuid.New(),
+		ContentID:     contentID,
+		PersonID:      input.PersonID,
+		Role:          input.Role,
+		CharacterName: input.CharacterName,
+		BillingOrder:  input.BillingOrder,
+	}
+	if err := uc.personRepo.CreateCredit(ctx, credit); err != nil {
+		return nil, err
+	}
+	credit.Person = person
+	return credit, nil
+}
+
+func (uc *PersonUseCase) RemoveCredit(ctx context.Context, contentID, creditID uuid.UUID) error {
+	return uc.personRepo.DeleteCredit(ctx, contentID, creditID)
+}
@@ -22,12 +22,16 @@ type CreatePlanInput struct {
 	ValidityDays      int                `json:"validity_days" binding:"required"`
 	AccessLevel       domain.AccessLevel `json:"access_level" binding:"required,oneof=free basic premium"`
 	MaxDevicesAllowed int                `json:"max_devices_allowed" binding:"required"`
-	Resolution        string             `json:"resolution"`
+	Resolution        domain.Resolution  `json:"resolution"`
 	Description       string             `json:"description"`
 	IsActive          bool               `json:"is_active"`
 }
 
 func (uc *PlanUseCase) CreatePlan(ctx context.Context, input CreatePlanInput) (*domain.Plan, error) {
+	resolution, err := planResolution(input.Resolution)
+	if err != nil {
+		return nil, err
+	}
 	plan := &domain.Plan{
 		ID:                uuid.New(),
 		Name:              input.Name,
@@ -35,7 +39,7 @@ func (uc *PlanUseCase) CreatePlan(ctx context.Context, input CreatePlanInput) (*
 		ValidityDays:      input.ValidityDays,
 		AccessLevel:       input.AccessLevel,
 		MaxDevicesAllowed: input.MaxDevicesAllowed,
-		Resolution:        input.Resolution,
+		Resolution:        resolution,
 		Description:       input.Description,
 		IsActive:          input.IsActive,
 	}
@@ -54,6 +58,10 @@ func (uc *PlanUseCase) ListPlans(ctx context.Context, activeOnly bool) ([]*domai
 }
 
 func (uc *PlanUseCase) UpdatePlan(ctx context.Context, planID uuid.UUID, input CreatePlanInput) (*domain.Plan, error) {
+	resolution, err := planResolution(input.Resolution)
+	if err != nil {
+		return nil, err
+	}
 	plan, err := uc.planRepo.GetByID(ctx, planID)
 	if err != nil {
 		return nil, err
@@ -63,7 +71,7 @@ func (uc *PlanUseCase) UpdatePlan(ctx context.Context, planID uuid.UUID, input C
 	plan.ValidityDays = input.ValidityDays
 	plan.AccessLevel = input.AccessLevel
 	plan.MaxDevicesAllowed = input.MaxDevicesAllowed
-	plan.Resolution = input.Resolution
+	plan.Resolution = resolution
 	plan.Description = input.Description
 	plan.IsActive = input.IsActive
 	if err := uc.planRepo.Update(ctx, plan); err != nil {
@@ -75,3 +83,22 @@ func (uc *PlanUseCase) UpdatePlan(ctx context.Context, planID uuid.UUID, input C
 func (uc *PlanUseCase) DeletePlan(ctx context.Context, planID uuid.UUID) error {
 	return uc.planRepo.Delete(ctx, planID)
 }
+
+func (uc *PlanUseCase) ListDeletedPlans(ctx context.Context) ([]*domain.Plan, error) {
+	return uc.planRepo.ListDeleted(ctx)
+}
+
+func (uc *PlanUseCase) RestorePlan(ctx context.Context, planID uuid.UUID) (*domain.Plan, error) {
+	if err := uc.planRepo.Restore(ctx, planID); err != nil {
+		return nil, err
+	}
+	return uc.planRepo.GetByID(ctx, planID)
+}
+
+// planResolution accepts resolutions in any case and stores the canonical form
+func planResolution(resolution domain.Resolution) (domain.Resolution, error) {
+	if resolution == "" {
+		return "", nil
+	}
+	return domain.ParseResolution(string(resolution))
+}
@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/manifest"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/signedurl"
+	"github.com/google/uuid"
+)
+
+type PlaybackUseCase struct {
+	contentUseCase   *ContentUseCase
+	renditionRepo    repositories.RenditionRepository
+	trackRepo        repositories.TrackRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	signer           *signedurl.Signer
+	ttl              time.Duration
+}
+
+func NewPlaybackUseCase(contentUseCase *ContentUseCase, renditionRepo repositories.RenditionRepository, trackRepo repositories.TrackRepository, subscriptionRepo repositories.SubscriptionRepository, signer *signedurl.Signer, ttl time.Duration) *PlaybackUseCase {
+	return &PlaybackUseCase{
+		contentUseCase:   contentUseCase,
+		renditionRepo:    renditionRepo,
+		trackRepo:        trackRepo,
+		subscriptionRepo: subscriptionRepo,
+		signer:           signer,
+		ttl:              ttl,
+	}
+}
+
+type PlaybackInput struct {
+	BindIP bool `json:"bind_ip"`
+}
+
+type PlaybackRendition struct {
+	Resolution  domain.Resolution `json:"resolution"`
+	BitrateKbps int               `json:"bitrate_kbps"`
+	Codec       string            `json:"codec"`
+	URL         string            `json:"url"`
+}
+
+type PlaybackSubtitle struct {
+	Language string                `json:"language"`
+	Label    string                `json:"label"`
+	Format   domain.SubtitleFormat `json:"format"`
+	IsForced bool                  `json:"is_forced"`
+	IsSDH    bool                  `json:"is_sdh"`
+	URL      string                `json:"url"`
+}
+
+type PlaybackAudioTrack struct {
+	Language    string `json:"language"`
+	Label       string `json:"label"`
+	Codec       string `json:"codec"`
+	Channels    int    `json:"channels"`
+	IsDefault   bool   `json:"is_default"`
+	IsDescribed bool   `json:"is_described"`
+	URL         string `json:"url"`
+}
+
+type PlaybackSession struct {
+	ContentID       uuid.UUID             `json:"content_id"`
+	URL             string                `json:"url"`
+	MaxResolution   domain.Resolution     `json:"max_resolution"`
+	DurationSeconds int                   `json:"duration_seconds"`
+	Renditions      []PlaybackRendition   `json:"renditions"`
+	Subtitles       []PlaybackSubtitle    `json:"subtitles"`
+	AudioTracks     []PlaybackAudioTrack  `json:"audio_tracks"`
+	Markers         domain.ContentMarkers `json:"markers"`
+	ExpiresAt       time.Time             `json:"expires_at"`
+}
+
+type ManifestFormat string
+
+const (
+	ManifestFormatHLS  ManifestFormat = "hls"
+	ManifestFormatDASH ManifestFormat = "dash"
+)
+
+type Manifest struct {
+	ContentType string
+	Body        []byte
+}
+
+func (uc *PlaybackUseCase) CreatePlaybackSession(ctx context.Context, contentID, userID uuid.UUID, country, clientIP string, input PlaybackInput) (*PlaybackSession, error) {
+	content, err := uc.contentUseCase.GetContent(ctx, contentID, &userID, country)
+	if err != nil {
+		return nil, err
+	}
+	maxResolution, err := userMaxResolution(ctx, uc.subscriptionRepo, &userID)
+	if err != nil {
+		return nil, err
+	}
+	renditions, err := uc.allowedRenditions(ctx, content.ID, maxResolution)
+	if err != nil {
+		return nil, err
+	}
+	claims := signedurl.Claims{
+		UserID:    userID.String(),
+		ExpiresAt: time.Now().Add(uc.ttl).Truncate(time.Second),
+	}
+	if input.BindIP {
+		claims.ClientIP = clientIP
+	}
+	session := &PlaybackSession{
+		ContentID:       content.ID,
+		MaxResolution:   maxResolution,
+		DurationSeconds: content.DurationSeconds,
+		Renditions:      make([]PlaybackRendition, 0, len(renditions)),
+		Markers:         content.Markers,
+		ExpiresAt:       claims.ExpiresAt,
+	}
+	for _, rendition := range renditions {
+		signed, err := uc.signer.Sign(rendition.URL, claims)
+		if err != nil {
+			return nil, err
+		}
+		session.Renditions = append(session.Renditions, PlaybackRendition{
+			Resolution:  rendition.Resolution,
+			BitrateKbps: rendition.BitrateKbps,
+			Codec:       rendition.Codec,
+			URL:         signed,
+		})
+	}
+	// Titles with renditions never expose the single source VideoURL, which
+	// may be above the plan cap, and default to the best allowed rendition
+	switch {
+	case len(session.Renditions) > 0:
+		session.URL = session.Renditions[len(session.Renditions)-1].URL
+	case content.VideoURL != "":
+		session.URL, err = uc.signer.Sign(content.VideoURL, claims)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, domain.ErrPlaybackUnavailable
+	}
+	if err := uc.attachTracks(ctx, session, claims); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// attachTracks lists subtitle and audio tracks with URLs signed under the
+// same claims as the video, which also unlocks API-hosted WebVTT files
+func (uc *PlaybackUseCase) attachTracks(ctx context.Context, session *PlaybackSession, claims signedurl.Claims) error {
+	subtitleTracks, err := uc.trackRepo.ListSubtitles(ctx, session.ContentID)
+	if err != nil {
+		return err
+	}
+	audioTracks, err := uc.trackRepo.ListAudioTracks(ctx, session.ContentID)
+	if err != nil {
+		return err
+	}
+	session.Subtitles = make([]PlaybackSubtitle, 0, len(subtitleTracks))
+	for _, track := range subtitleTracks {
+		signed, err := uc.signer.Sign(track.URL, claims)
+		if err != nil {
+			return err
+		}
+		session.Subtitles = append(session.Subtitles, PlaybackSubtitle{
+			Language: track.Language,
+			Label:    track.Label,
+			Format:   track.Format,
+			IsForced: track.IsForced,
+			IsSDH:    track.IsSDH,
+			URL:      signed,
+		})
+	}
+	session.AudioTracks = make([]PlaybackAudioTrack, 0, len(audioTracks))
+	for _, track := range audioTracks {
+		signed, err := uc.signer.Sign(track.URL, claims)
+		if err != nil {
+			return err
+		}
+		session.AudioTracks = append(session.AudioTracks, PlaybackAudioTrack{
+			Language:    track.Language,
+			Label:       track.Label,
+			Codec:       track.Codec,
+			Channels:    track.Channels,
+			IsDefault:   track.IsDefault,
+			IsDescribed: track.IsDescribed,
+			URL:         signed,
+		})
+	}
+	return nil
+}
+
+// CreateManifest builds a master manifest over the same entitled, signed
+// renditions a playback session exposes, so variants above the plan cap are
+// never listed
+func (uc *PlaybackUseCase) CreateManifest(ctx context.Context, contentID, userID uuid.UUID, country, clientIP string, format ManifestFormat, input PlaybackInput) (*Manifest, error) {
+	if format != ManifestFormatHLS && format != ManifestFormatDASH {
+		return nil, domain.ErrInvalidManifestFormat
+	}
+	session, err := uc.CreatePlaybackSession(ctx, contentID, userID, country, clientIP, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(session.Renditions) == 0 {
+		return nil, domain.ErrPlaybackUnavailable
+	}
+	variants := make([]manifest.Variant, 0, len(session.Renditions))
+	for _, rendition := range session.Renditions {
+		width, height := rendition.Resolution.Dimensions()
+		variants = append(variants, manifest.Variant{
+			ID:            fmt.Sprintf("%s-%d", rendition.Resolution, rendition.BitrateKbps),
+			BandwidthKbps: rendition.BitrateKbps,
+			Width:         width,
+			Height:        height,
+			Codecs:        rendition.Codec,
+			URI:           rendition.URL,
+		})
+	}
+	if format == ManifestFormatDASH {
+		body, err := manifest.DASH(variants, time.Duration(session.DurationSeconds)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		return &Manifest{ContentType: manifest.DASHContentType, Body: body}, nil
+	}
+	return &Manifest{ContentType: manifest.HLSContentType, Body: manifest.HLS(variants)}, nil
+}
+
+// allowedRenditions returns renditions at or below the cap ordered by bitrate,
+// errors with ErrPlaybackUnavailable when renditions exist but none fit the cap
+func (uc *PlaybackUseCase) allowedRenditions(ctx context.Context, contentID uuid.UUID, maxResolution domain.Resolution) ([]*domain.VideoRendition, error) {
+	renditions, err := uc.renditionRepo.GetByContentID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make([]*domain.VideoRendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		if rendition.Resolution.AtMost(maxResolution) {
+			allowed = append(allowed, rendition)
+		}
+	}
+	if len(renditions) > 0 && len(allowed) == 0 {
+		return nil, domain.ErrPlaybackUnavailable
+	}
+	return allowed, nil
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+)
+
+// ProgressFlushJob writes buffered playback progress to the database in
+// batches. Heartbeats arriving between runs are coalesced in the buffer, so
+// each title a user watched costs one write per run.
+type ProgressFlushJob struct {
+	buffer           infrastructure.ProgressBufferInterface
+	watchHistoryRepo repositories.WatchHistoryRepository
+	batchUsers       int
+}
+
+func NewProgressFlushJob(buffer infrastructure.ProgressBufferInterface, watchHistoryRepo repositories.WatchHistoryRepository, batchUsers int) *ProgressFlushJob {
+	return &ProgressFlushJob{buffer: buffer, watchHistoryRepo: watchHistoryRepo, batchUsers: batchUsers}
+}
+
+func (j *ProgressFlushJob) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Run(ctx); err != nil {
+				log.Printf("Progress flush job run failed: %v", err)
+			}
+		}
+	}
+}
+
+// Run flushes batches until the buffer has nothing left to write. Entries are
+// acknowledged only after their batch is stored, so a failed run loses nothing.
+func (j *ProgressFlushJob) Run(ctx context.Context) error {
+	for {
+		histories, err := j.buffer.Drain(ctx, j.batchUsers)
+		if err != nil {
+			return err
+		}
+		if len(histories) == 0 {
+			return nil
+		}
+		if err := j.watchHistoryRepo.UpdateProgressBatch(ctx, histories); err != nil {
+			return err
+		}
+		if err := j.buffer.Ack(ctx, histories); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxRecommendations = 50
+
+	RecommendationReasonSimilar = "because_you_watched"
+	RecommendationReasonPopular = "popular"
+)
+
+const (
+	recommendationSeedCount     = 50
+	recommendationNeighbours    = 20
+	recommendationPopularWindow = 30 * 24 * time.Hour
+	recommendationCacheTTL      = 30 * time.Minute
+	recommendationGenerationKey = "recommendations:generation"
+	// Pairs need a few shared viewers before the score means anything
+	recommendationMinCoViewers   = 2
+	recommendationSimilarPerItem = 50
+)
+
+type RecommendationSeed struct {
+	ContentID uuid.UUID `json:"content_id"`
+	Title     string    `json:"title"`
+}
+
+type Recommendation struct {
+	Content           *domain.Content     `json:"content"`
+	Score             float64             `json:"score"`
+	Reason            string              `json:"reason"`
+	BecauseYouWatched *RecommendationSeed `json:"because_you_watched,omitempty"`
+}
+
+type RecommendationUseCase struct {
+	recommendationRepo repositories.RecommendationRepository
+	watchHistoryRepo   repositories.WatchHistoryRepository
+	subscriptionRepo   repositories.SubscriptionRepository
+	cache              infrastructure.CacheInterface
+}
+
+func NewRecommendationUseCase(recommendationRepo repositories.RecommendationRepository, watchHistoryRepo repositories.WatchHistoryRepository, subscriptionRepo repositories.SubscriptionRepository, cache infrastructure.CacheInterface) *RecommendationUseCase {
+	return &RecommendationUseCase{
+		recommendationRepo: recommendationRepo,
+		watchHistoryRepo:   watchHistoryRepo,
+		subscriptionRepo:   subscriptionRepo,
+		cache:              cache,
+	}
+}
+
+// GetRecommendations scores neighbours of the user's recent titles, weighting
+// each seed by how much of it was watched, and tops the list up with popular
+// content so new users still get a full page. Anything the user has already
+// started is left out, completed titles are done and the rest are in continue watching.
+func (uc *RecommendationUseCase) GetRecommendations(ctx context.Context, userID uuid.UUID, country string, limit int) ([]*Recommendation, error) {
+	if limit <= 0 || limit > MaxRecommendations {
+		limit = MaxRecommendations
+	}
+	level, err := userAccessLevel(ctx, uc.subscriptionRepo, &userID)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := uc.cacheKey(ctx, userID, level, country, limit)
+	if cached, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var recommendations []*Recommendation
+		if json.Unmarshal([]byte(cached), &recommendations) == nil {
+			return recommendations, nil
+		}
+	}
+
+	seeds, _, err := uc.watchHistoryRepo.GetByUserID(ctx, userID, repositories.Page{
+		Sort: repositories.SortLastWatchedAt, Desc: true, Limit: recommendationSeedCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	seedWeights := make(map[uuid.UUID]float64, len(seeds))
+	seedTitles := make(map[uuid.UUID]string, len(seeds))
+	seedIDs := make([]uuid.UUID, 0, len(seeds))
+	for _, seed := range seeds {
+		weight := 1.0
+		if seed.Status != domain.WatchStatusCompleted {
+			weight = clampFloat(seed.ProgressPercentage()/100, 0.2, 1)
+		}
+		seedWeights[seed.ContentID] = weight
+		seedIDs = append(seedIDs, seed.ContentID)
+		if seed.Content != nil {
+			seedTitles[seed.ContentID] = seed.Content.Title
+		}
+	}
+	similarities, err := uc.recommendationRepo.ListSimilar(ctx, seedIDs, recommendationNeighbours)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	viewable := func(content *domain.Content) bool {
+		return content != nil && content.IsAvailableAt(now) && content.IsAvailableInCountry(country) && level.Allows(content.AccessLevel)
+	}
+	candidates := make(map[uuid.UUID]*Recommendation)
+	bestSeed := make(map[uuid.UUID]float64)
+	for _, similarity := range similarities {
+		if _, seen := seedWeights[similarity.SimilarContentID]; seen || !viewable(similarity.SimilarContent) {
+			continue
+		}
+		contribution := similarity.Score * seedWeights[similarity.ContentID]
+		candidate := candidates[similarity.SimilarContentID]
+		if candidate == nil {
+			candidate = &Recommendation{Content: similarity.SimilarContent, Reason: RecommendationReasonSimilar}
+			candidates[similarity.SimilarContentID] = candidate
+		}
+		candidate.Score += contribution
+		if contribution > bestSeed[similarity.SimilarContentID] {
+			bestSeed[similarity.SimilarContentID] = contribution
+			candidate.BecauseYouWatched = &RecommendationSeed{ContentID: similarity.ContentID, Title: seedTitles[similarity.ContentID]}
+		}
+	}
+	if len(candidates) < limit {
+		popular, err := uc.recommendationRepo.ListPopular(ctx, now.Add(-recommendationPopularWindow), limit+len(seedIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range popular {
+			if _, seen := seedWeights[content.ID]; seen || candidates[content.ID] != nil || !viewable(content) {
+				continue
+			}
+			candidates[content.ID] = &Recommendation{Content: content, Reason: RecommendationReasonPopular}
+		}
+	}
+
+	// Seeds only cover recent history, so older titles are checked explicitly
+	candidateIDs := make([]uuid.UUID, 0, len(candidates))
+	for id := range candidates {
+		candidateIDs = append(candidateIDs, id)
+	}
+	watched, err := uc.watchHistoryRepo.GetByUserAndContentIDs(ctx, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, history := range watched {
+		delete(candidates, history.ContentID)
+	}
+
+	recommendations := make([]*Recommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		recommendations = append(recommendations, candidate)
+	}
+	// Personal matches always rank above the popular fallback
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Reason != b.Reason {
+			return a.Reason == RecommendationReasonSimilar
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Content.ID.String() < b.Content.ID.String()
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	if encoded, err := json.Marshal(recommendations); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, encoded, recommendationCacheTTL); err != nil {
+			log.Printf("cache recommendations for user %s: %v", userID, err)
+		}
+	}
+	return recommendations, nil
+}
+
+// HandleContentEvent bumps the generation when a title is published or
+// expires, so cached lists pick it up or drop it on the next request
+func (uc *RecommendationUseCase) HandleContentEvent(ctx context.Context, event domain.ContentEvent) {
+	if _, err := uc.cache.Increment(ctx, recommendationGenerationKey); err != nil {
+		log.Printf("invalidate recommendations after %s %s: %v", event.Type, event.ContentID, err)
+	}
+}
+
+// cacheKey embeds the batch generation so a finished rebuild invalidates
+// every cached list at once without scanning keys
+func (uc *RecommendationUseCase) cacheKey(ctx context.Context, userID uuid.UUID, level domain.AccessLevel, country string, limit int) string {
+	generation, err := uc.cache.Get(ctx, recommendationGenerationKey)
+	if err != nil {
+		generation = "0"
+	}
+	return fmt.Sprintf("recommendations:%s:%s:%s:%s:%d", generation, userID, level, country, limit)
+}
+
+func clampFloat(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// RecommendationJob periodically rebuilds the item-to-item similarity table
+// from watch history and bumps the cache generation afterwards
+type RecommendationJob struct {
+	recommendationRepo repositories.RecommendationRepository
+	cache              infrastructure.CacheInterface
+}
+
+func NewRecommendationJob(recommendationRepo repositories.RecommendationRepository, cache infrastructure.CacheInterface) *RecommendationJob {
+	return &RecommendationJob{recommendationRepo: recommendationRepo, cache: cache}
+}
+
+func (j *RecommendationJob) Start(ctx context.Context, interval time.Duration) {
+	if err := j.Run(ctx); err != nil {
+		log.Printf("Recommendation job run failed: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Run(ctx); err != nil {
+				log.Printf("Recommendation job run failed: %v", err)
+			}
+		}
+	}
+}
+
+func (j *RecommendationJob) Run(ctx context.Context) error {
+	rows, err := j.recommendationRepo.RebuildSimilarities(ctx, recommendationMinCoViewers, recommendationSimilarPerItem)
+	if err != nil {
+		return err
+	}
+	if _, err := j.cache.Increment(ctx, recommendationGenerationKey); err != nil {
+		return err
+	}
+	log.Printf("Recommendation job stored %d similar pairs", rows)
+	return nil
+}
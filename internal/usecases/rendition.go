@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type RenditionUseCase struct {
+	renditionRepo repositories.RenditionRepository
+	contentRepo   repositories.ContentRepository
+}
+
+func NewRenditionUseCase(renditionRepo repositories.RenditionRepository, contentRepo repositories.ContentRepository) *RenditionUseCase {
+	return &RenditionUseCase{renditionRepo: renditionRepo, contentRepo: contentRepo}
+}
+
+type CreateRenditionInput struct {
+	Resolution  domain.Resolution `json:"resolution" binding:"required,oneof=480p 720p 1080p 4K"`
+	BitrateKbps int               `json:"bitrate_kbps" binding:"required,gt=0"`
+	Codec       string            `json:"codec" binding:"required"`
+	URL         string            `json:"url" binding:"required,url"`
+}
+
+func (uc *RenditionUseCase) AddRendition(ctx context.Context, contentID uuid.UUID, input CreateRenditionInput) (*domain.VideoRendition, error) {
+	if !input.Resolution.IsValid() {
+		return nil, domain.ErrInvalidResolution
+	}
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	existing, err := uc.renditionRepo.GetByContentID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rendition := range existing {
+		if rendition.Resolution == input.Resolution && rendition.Codec == input.Codec {
+			return nil, domain.ErrRenditionExists
+		}
+	}
+	rendition := &domain.VideoRendition{
+		ID:          uuid.New(),
+		ContentID:   contentID,
+		Resolution:  input.Resolution,
+		BitrateKbps: input.BitrateKbps,
+		Codec:       input.Codec,
+		URL:         input.URL,
+	}
+	if err := uc.renditionRepo.Create(ctx, rendition); err != nil {
+		return nil, err
+	}
+	return rendition, nil
+}
+
+func (uc *RenditionUseCase) ListRenditions(ctx context.Context, contentID uuid.UUID) ([]*domain.VideoRendition, error) {
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return uc.renditionRepo.GetByContentID(ctx, contentID)
+}
+
+func (uc *RenditionUseCase) RemoveRendition(ctx context.Context, contentID, renditionID uuid.UUID) error {
+	return uc.renditionRepo.Delete(ctx, contentID, renditionID)
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type ReviewUseCase struct {
+	reviewRepo       repositories.ReviewRepository
+	watchHistoryRepo repositories.WatchHistoryRepository
+}
+
+func NewReviewUseCase(reviewRepo repositories.ReviewRepository, watchHistoryRepo repositories.WatchHistoryRepository) *ReviewUseCase {
+	return &ReviewUseCase{reviewRepo: reviewRepo, watchHistoryRepo: watchHistoryRepo}
+}
+
+type ReviewInput struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Body   string `json:"body" binding:"max=5000"`
+}
+
+type ModerateReviewInput struct {
+	Status domain.ReviewStatus `json:"status" binding:"required,oneof=approved rejected"`
+	Note   string              `json:"note"`
+}
+
+// RateContent creates or replaces the user's rating. Only viewers with watch
+// history may rate, and any text goes back to moderation when it changes.
+func (uc *ReviewUseCase) RateContent(ctx context.Context, userID, contentID uuid.UUID, input ReviewInput) (*domain.Review, error) {
+	if input.Rating < 1 || input.Rating > 5 {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := uc.watchHistoryRepo.GetByUserAndContent(ctx, userID, contentID); err != nil {
+		if err == domain.ErrWatchHistoryNotFound {
+			return nil, domain.ErrRatingRequiresWatch
+		}
+		return nil, err
+	}
+	body := strings.TrimSpace(input.Body)
+	review, err := uc.reviewRepo.GetByUserAndContent(ctx, userID, contentID)
+	if err != nil && err != domain.ErrReviewNotFound {
+		return nil, err
+	}
+	if review == nil {
+		review = &domain.Review{ID: uuid.New(), UserID: userID, ContentID: contentID}
+	}
+	if review.Body != body || review.Status == "" {
+		review.Body = body
+		review.Status = domain.ReviewStatusPending
+		review.ModerationNote, review.ModeratedBy, review.ModeratedAt = "", nil, nil
+		// Bare ratings carry nothing to moderate
+		if body == "" {
+			review.Status = domain.ReviewStatusApproved
+		}
+	}
+	review.Rating = input.Rating
+	if err := uc.reviewRepo.Upsert(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (uc *ReviewUseCase) GetMyReview(ctx context.Context, userID, contentID uuid.UUID) (*domain.Review, error) {
+	return uc.reviewRepo.GetByUserAndContent(ctx, userID, contentID)
+}
+
+func (uc *ReviewUseCase) DeleteMyReview(ctx context.Context, userID, contentID uuid.UUID) error {
+	review, err := uc.reviewRepo.GetByUserAndContent(ctx, userID, contentID)
+	if err != nil {
+		return err
+	}
+	return uc.reviewRepo.Delete(ctx, review.ID)
+}
+
+func (uc *ReviewUseCase) ListContentReviews(ctx context.Context, contentID uuid.UUID, limit, offset int) ([]*domain.Review, int64, error) {
+	return uc.reviewRepo.ListPublished(ctx, contentID, limit, offset)
+}
+
+func (uc *ReviewUseCase) ListModerationQueue(ctx context.Context, status domain.ReviewStatus, limit, offset int) ([]*domain.Review, int64, error) {
+	if status == "" {
+		status = domain.ReviewStatusPending
+	}
+	switch status {
+	case domain.ReviewStatusPending, domain.ReviewStatusApproved, domain.ReviewStatusRejected:
+	default:
+		return nil, 0, domain.ErrInvalidReviewStatus
+	}
+	return uc.reviewRepo.ListByStatus(ctx, status, limit, offset)
+}
+
+func (uc *ReviewUseCase) ModerateReview(ctx context.Context, moderatorID, reviewID uuid.UUID, input ModerateReviewInput) (*domain.Review, error) {
+	if input.Status != domain.ReviewStatusApproved && input.Status != domain.ReviewStatusRejected {
+		return nil, domain.ErrInvalidReviewStatus
+	}
+	review, err := uc.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	review.Status = input.Status
+	review.ModerationNote = input.Note
+	review.ModeratedBy = &moderatorID
+	review.ModeratedAt = &now
+	if err := uc.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (uc *ReviewUseCase) DeleteReview(ctx context.Context, reviewID uuid.UUID) error {
+	return uc.reviewRepo.Delete(ctx, reviewID)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	favoriteGenresLimit  = 3
+	reviewTopTitlesLimit = 5
+	statsCacheTTL        = 10 * time.Minute
+	// A finished year no longer changes beyond late progress flushes
+	pastYearReviewCacheTTL = 24 * time.Hour
+)
+
+type StatsUseCase struct {
+	watchHistoryRepo repositories.WatchHistoryRepository
+	cache            infrastructure.CacheInterface
+}
+
+func NewStatsUseCase(watchHistoryRepo repositories.WatchHistoryRepository, cache infrastructure.CacheInterface) *StatsUseCase {
+	return &StatsUseCase{watchHistoryRepo: watchHistoryRepo, cache: cache}
+}
+
+// GetStats summarizes the user's viewing over the rolling period. Results are
+// cached per user and period, so they may trail live progress by a few minutes.
+func (uc *StatsUseCase) GetStats(ctx context.Context, userID uuid.UUID, period domain.StatsPeriod) (*domain.ViewerStats, error) {
+	now := time.Now().UTC()
+	from, ok := period.Since(now)
+	if !ok {
+		return nil, domain.ErrInvalidStatsPeriod
+	}
+	cacheKey := fmt.Sprintf("user_stats:%s:%s", userID, period)
+	stats := &domain.ViewerStats{}
+	if uc.cached(ctx, cacheKey, stats) {
+		return stats, nil
+	}
+	stats = &domain.ViewerStats{Period: period, To: now}
+	if !from.IsZero() {
+		stats.From = &from
+	}
+	totals, err := uc.watchHistoryRepo.GetWatchTotals(ctx, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+	stats.WatchTotals = *totals
+	if stats.FavoriteGenres, err = uc.watchHistoryRepo.GetTopGenres(ctx, userID, from, now, favoriteGenresLimit); err != nil {
+		return nil, err
+	}
+	days, err := uc.watchHistoryRepo.GetActivityDays(ctx, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+	stats.Streaks = domain.ComputeStreaks(days, now)
+	uc.store(ctx, cacheKey, stats, statsCacheTTL)
+	return stats, nil
+}
+
+// GetYearInReview recaps a calendar year in UTC with the user's most watched
+// titles and a month by month breakdown
+func (uc *StatsUseCase) GetYearInReview(ctx context.Context, userID uuid.UUID, year int) (*domain.YearInReview, error) {
+	now := time.Now().UTC()
+	if year < 1970 || year > now.Year() {
+		return nil, domain.ErrInvalidReviewYear
+	}
+	cacheKey := fmt.Sprintf("year_in_review:%s:%d", userID, year)
+	review := &domain.YearInReview{}
+	if uc.cached(ctx, cacheKey, review) {
+		return review, nil
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	review = &domain.YearInReview{Year: year}
+	totals, err := uc.watchHistoryRepo.GetWatchTotals(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	review.WatchTotals = *totals
+	if review.FavoriteGenres, err = uc.watchHistoryRepo.GetTopGenres(ctx, userID, from, to, favoriteGenresLimit); err != nil {
+		return nil, err
+	}
+	if review.TopTitles, err = uc.watchHistoryRepo.GetTopTitles(ctx, userID, from, to, reviewTopTitlesLimit); err != nil {
+		return nil, err
+	}
+	months, err := uc.watchHistoryRepo.GetMonthlyActivity(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	review.Months = fillMonths(months)
+	days, err := uc.watchHistoryRepo.GetActivityDays(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	review.Streaks = domain.ComputeStreaks(days, now)
+	ttl := statsCacheTTL
+	if year < now.Year() {
+		ttl = pastYearReviewCacheTTL
+	}
+	uc.store(ctx, cacheKey, review, ttl)
+	return review, nil
+}
+
+// fillMonths lists all twelve months, with zeros for months without viewing
+func fillMonths(activity []*domain.MonthActivity) []*domain.MonthActivity {
+	months := make([]*domain.MonthActivity, 12)
+	for i := range months {
+		months[i] = &domain.MonthActivity{Month: i + 1}
+	}
+	for _, month := range activity {
+		if month.Month >= 1 && month.Month <= 12 {
+			months[month.Month-1] = month
+		}
+	}
+	return months
+}
+
+func (uc *StatsUseCase) cached(ctx context.Context, key string, target interface{}) bool {
+	cached, err := uc.cache.Get(ctx, key)
+	return err == nil && json.Unmarshal([]byte(cached), target) == nil
+}
+
+func (uc *StatsUseCase) store(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if err := uc.cache.Set(ctx, key, encoded, ttl); err != nil {
+		log.Printf("cache %s: %v", key, err)
+	}
+}
@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/signedurl"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/subtitles"
+	"github.com/google/uuid"
+)
+
+// Uploaded subtitles are stored as WebVTT and served by the API at this path
+const hostedSubtitlePath = "/api/v1/content/%s/subtitles/%s.vtt"
+
+type TrackUseCase struct {
+	trackRepo   repositories.TrackRepository
+	contentRepo repositories.ContentRepository
+	signer      *signedurl.Signer
+}
+
+func NewTrackUseCase(trackRepo repositories.TrackRepository, contentRepo repositories.ContentRepository, signer *signedurl.Signer) *TrackUseCase {
+	return &TrackUseCase{trackRepo: trackRepo, contentRepo: contentRepo, signer: signer}
+}
+
+type AddSubtitleInput struct {
+	Language string                `json:"language" form:"language" binding:"required"`
+	Label    string                `json:"label" form:"label"`
+	Format   domain.SubtitleFormat `json:"format" form:"format" binding:"omitempty,oneof=webvtt srt"`
+	URL      string                `json:"url" form:"url" binding:"omitempty,url"`
+	IsForced bool                  `json:"is_forced" form:"is_forced"`
+	IsSDH    bool                  `json:"is_sdh" form:"is_sdh"`
+	File     []byte                `json:"-" form:"-"`
+}
+
+type AddAudioTrackInput struct {
+	Language    string `json:"language" binding:"required"`
+	Label       string `json:"label"`
+	Codec       string `json:"codec" binding:"required"`
+	Channels    int    `json:"channels" binding:"omitempty,min=1,max=16"`
+	IsDefault   bool   `json:"is_default"`
+	IsDescribed bool   `json:"is_described"`
+	URL         string `json:"url" binding:"required,url"`
+}
+
+type ContentTracks struct {
+	Subtitles   []*domain.SubtitleTrack `json:"subtitles"`
+	AudioTracks []*domain.AudioTrack    `json:"audio_tracks"`
+}
+
+func (uc *TrackUseCase) AddSubtitle(ctx context.Context, contentID uuid.UUID, input AddSubtitleInput) (*domain.SubtitleTrack, error) {
+	language, err := domain.NormalizeLanguageTag(input.Language)
+	if err != nil {
+		return nil, err
+	}
+	if (input.URL == "") == (len(input.File) == 0) {
+		return nil, domain.ErrSubtitleSource
+	}
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	existing, err := uc.trackRepo.ListSubtitles(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, track := range existing {
+		if track.Language == language && track.IsForced == input.IsForced && track.IsSDH == input.IsSDH {
+			return nil, domain.ErrTrackExists
+		}
+	}
+	track := &domain.SubtitleTrack{
+		ID:        uuid.New(),
+		ContentID: contentID,
+		Language:  language,
+		Label:     input.Label,
+		Format:    input.Format,
+		URL:       input.URL,
+		IsForced:  input.IsForced,
+		IsSDH:     input.IsSDH,
+	}
+	if len(input.File) > 0 {
+		body, err := toWebVTT(input.File, input.Format)
+		if err != nil {
+			return nil, err
+		}
+		track.Format = domain.SubtitleFormatWebVTT
+		track.Body = string(body)
+		track.URL = fmt.Sprintf(hostedSubtitlePath, contentID, track.ID)
+	} else if track.Format == "" {
+		track.Format = domain.SubtitleFormatWebVTT
+		if strings.EqualFold(path.Ext(strings.SplitN(input.URL, "?", 2)[0]), ".srt") {
+			track.Format = domain.SubtitleFormatSRT
+		}
+	}
+	if err := uc.trackRepo.CreateSubtitle(ctx, track); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
+
+// toWebVTT converts SRT uploads on ingest so players only ever fetch WebVTT,
+// sniffing the format when the admin did not declare one
+func toWebVTT(file []byte, format domain.SubtitleFormat) ([]byte, error) {
+	if format == "" && subtitles.ValidateWebVTT(file) == nil {
+		format = domain.SubtitleFormatWebVTT
+	}
+	if format == domain.SubtitleFormatWebVTT {
+		if err := subtitles.ValidateWebVTT(file); err != nil {
+			return nil, domain.ErrInvalidSubtitle
+		}
+		return file, nil
+	}
+	body, err := subtitles.SRTToWebVTT(file)
+	if err != nil {
+		return nil, domain.ErrInvalidSubtitle
+	}
+	return body, nil
+}
+
+func (uc *TrackUseCase) AddAudioTrack(ctx context.Context, contentID uuid.UUID, input AddAudioTrackInput) (*domain.AudioTrack, error) {
+	language, err := domain.NormalizeLanguageTag(input.Language)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	existing, err := uc.trackRepo.ListAudioTracks(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, track := range existing {
+		if track.Language == language && track.Codec == input.Codec && track.IsDescribed == input.IsDescribed {
+			return nil, domain.ErrTrackExists
+		}
+		if input.IsDefault && track.IsDefault {
+			return nil, domain.ErrDefaultTrackExists
+		}
+	}
+	channels := input.Channels
+	if channels == 0 {
+		channels = 2
+	}
+	track := &domain.AudioTrack{
+		ID:          uuid.New(),
+		ContentID:   contentID,
+		Language:    language,
+		Label:       input.Label,
+		Codec:       input.Codec,
+		Channels:    channels,
+		IsDefault:   input.IsDefault,
+		IsDescribed: input.IsDescribed,
+		URL:         input.URL,
+	}
+	if err := uc.trackRepo.CreateAudioTrack(ctx, track); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
+
+func (uc *TrackUseCase) ListTracks(ctx context.Context, contentID uuid.UUID) (*ContentTracks, error) {
+	if _, err := uc.contentRepo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	subtitleTracks, err := uc.trackRepo.ListSubtitles(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	audioTracks, err := uc.trackRepo.ListAudioTracks(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentTracks{Subtitles: subtitleTracks, AudioTracks: audioTracks}, nil
+}
+
+func (uc *TrackUseCase) RemoveSubtitle(ctx context.Context, contentID, trackID uuid.UUID) error {
+	return uc.trackRepo.DeleteSubtitle(ctx, contentID, trackID)
+}
+
+func (uc *TrackUseCase) RemoveAudioTrack(ctx context.Context, contentID, trackID uuid.UUID) error {
+	return uc.trackRepo.DeleteAudioTrack(ctx, contentID, trackID)
+}
+
+// GetSubtitleFile serves a hosted WebVTT body to holders of a signed URL
+// issued with a playback session, so players need no auth header to fetch it
+func (uc *TrackUseCase) GetSubtitleFile(ctx context.Context, contentID, trackID uuid.UUID, requestURI, clientIP string) ([]byte, error) {
+	if _, err := uc.signer.Verify(requestURI, clientIP, time.Now()); err != nil {
+		return nil, domain.ErrForbidden
+	}
+	track, err := uc.trackRepo.GetSubtitle(ctx, contentID, trackID)
+	if err != nil {
+		return nil, err
+	}
+	if track.Body == "" {
+		return nil, domain.ErrTrackNotFound
+	}
+	return []byte(track.Body), nil
+}
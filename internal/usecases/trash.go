@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+)
+
+// TrashPurgeJob permanently removes content and plans that have been in the
+// trash for longer than the retention period
+type TrashPurgeJob struct {
+	contentRepo repositories.ContentRepository
+	planRepo    repositories.PlanRepository
+	retention   time.Duration
+}
+
+func NewTrashPurgeJob(contentRepo repositories.ContentRepository, planRepo repositories.PlanRepository, retention time.Duration) *TrashPurgeJob {
+	return &TrashPurgeJob{contentRepo: contentRepo, planRepo: planRepo, retention: retention}
+}
+
+func (j *TrashPurgeJob) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := j.Run(ctx, now); err != nil {
+				log.Printf("Trash purge job run failed: %v", err)
+			}
+		}
+	}
+}
+
+func (j *TrashPurgeJob) Run(ctx context.Context, now time.Time) error {
+	cutoff := now.Add(-j.retention)
+	contents, err := j.contentRepo.Purge(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	plans, err := j.planRepo.Purge(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if contents > 0 || plans > 0 {
+		log.Printf("Trash purge job removed %d content and %d plans", contents, plans)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+)
+
+const (
+	MaxTrendingLimit      = 50
+	trendingGenerationKey = "trending:generation"
+)
+
+// Daily charts move faster, so they are cached for less time
+var trendingCacheTTL = map[domain.TrendingWindow]time.Duration{
+	domain.TrendingWindowDay:  5 * time.Minute,
+	domain.TrendingWindowWeek: 30 * time.Minute,
+}
+
+type TrendingUseCase struct {
+	trendingRepo repositories.TrendingRepository
+	cache        infrastructure.CacheInterface
+}
+
+func NewTrendingUseCase(trendingRepo repositories.TrendingRepository, cache infrastructure.CacheInterface) *TrendingUseCase {
+	return &TrendingUseCase{trendingRepo: trendingRepo, cache: cache}
+}
+
+// GetTrending returns the most watched titles over the rolling window, ranked
+// by unique viewers then completions and watch time. Results are per country
+// when the viewer's country is known and global otherwise.
+func (uc *TrendingUseCase) GetTrending(ctx context.Context, window domain.TrendingWindow, country string, limit int) ([]*domain.ContentActivity, error) {
+	duration, ok := window.Duration()
+	if !ok {
+		return nil, domain.ErrInvalidTrendingWindow
+	}
+	if limit <= 0 || limit > MaxTrendingLimit {
+		limit = MaxTrendingLimit
+	}
+	cacheKey := uc.cacheKey(ctx, window, country, limit)
+	if cached, err := uc.cache.Get(ctx, cacheKey); err == nil {
+		var activity []*domain.ContentActivity
+		if json.Unmarshal([]byte(cached), &activity) == nil {
+			return activity, nil
+		}
+	}
+	activity, err := uc.trendingRepo.ListTrending(ctx, time.Now().Add(-duration), country, limit)
+	if err != nil {
+		return nil, err
+	}
+	if encoded, err := json.Marshal(activity); err == nil {
+		if err := uc.cache.Set(ctx, cacheKey, encoded, trendingCacheTTL[window]); err != nil {
+			log.Printf("cache trending %s chart: %v", window, err)
+		}
+	}
+	return activity, nil
+}
+
+// HandleContentEvent drops every cached chart when a title is published or
+// expires, so the chart and the home rail built from it stop listing it
+func (uc *TrendingUseCase) HandleContentEvent(ctx context.Context, event domain.ContentEvent) {
+	if _, err := uc.cache.Increment(ctx, trendingGenerationKey); err != nil {
+		log.Printf("invalidate trending charts after %s %s: %v", event.Type, event.ContentID, err)
+	}
+}
+
+// cacheKey embeds the generation so an invalidation reaches every window,
+// country and limit without scanning keys
+func (uc *TrendingUseCase) cacheKey(ctx context.Context, window domain.TrendingWindow, country string, limit int) string {
+	generation, err := uc.cache.Get(ctx, trendingGenerationKey)
+	if err != nil {
+		generation = "0"
+	}
+	return fmt.Sprintf("trending:%s:%s:%s:%d", generation, window, country, limit)
+}
@@ -19,10 +19,11 @@ func NewUserUseCase(userRepo repositories.UserRepository, subscriptionRepo repos
 }
 
 type UpdateProfileInput struct {
-	Name    string `json:"name"`
-	Bio     string `json:"bio"`
-	Picture string `json:"picture"`
-	Phone   string `json:"phone"`
+	Name            string `json:"name"`
+	Bio             string `json:"bio"`
+	Picture         string `json:"picture"`
+	Phone           string `json:"phone"`
+	PreferredLocale string `json:"preferred_locale"`
 }
 
 func (uc *UserUseCase) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
@@ -46,6 +47,13 @@ func (uc *UserUseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, inpu
 	if input.Phone != "" {
 		user.Phone = input.Phone
 	}
+	if input.PreferredLocale != "" {
+		locale, err := domain.NormalizeLanguageTag(input.PreferredLocale)
+		if err != nil {
+			return nil, err
+		}
+		user.PreferredLocale = locale
+	}
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
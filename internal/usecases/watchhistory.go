@@ -2,24 +2,43 @@ package usecases
 
 import (
 	"context"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/google/uuid"
 )
 
+const (
+	// A completed title played again from within this many seconds of the
+	// start counts as a rewatch
+	rewatchStartSeconds = 60
+	// Offline clients may run slightly fast, anything further ahead is rejected
+	maxClientClockSkew    = 5 * time.Minute
+	continueWatchingLimit = 10
+)
+
 type WatchHistoryUseCase struct {
 	watchHistoryRepo repositories.WatchHistoryRepository
 	contentRepo      repositories.ContentRepository
 	subscriptionRepo repositories.SubscriptionRepository
+	watchlistRepo    repositories.WatchlistRepository
+	completion       domain.CompletionPolicies
+	// progressBuffer is optional, without it progress is written straight through
+	progressBuffer infrastructure.ProgressBufferInterface
 }
 
-func NewWatchHistoryUseCase(watchHistoryRepo repositories.WatchHistoryRepository, contentRepo repositories.ContentRepository, subscriptionRepo repositories.SubscriptionRepository) *WatchHistoryUseCase {
+func NewWatchHistoryUseCase(watchHistoryRepo repositories.WatchHistoryRepository, contentRepo repositories.ContentRepository, subscriptionRepo repositories.SubscriptionRepository, watchlistRepo repositories.WatchlistRepository, completion domain.CompletionPolicies, progressBuffer infrastructure.ProgressBufferInterface) *WatchHistoryUseCase {
 	return &WatchHistoryUseCase{
 		watchHistoryRepo: watchHistoryRepo,
 		contentRepo:      contentRepo,
 		subscriptionRepo: subscriptionRepo,
+		watchlistRepo:    watchlistRepo,
+		completion:       completion,
+		progressBuffer:   progressBuffer,
 	}
 }
 
@@ -28,15 +47,52 @@ type WatchHistoryInput struct {
 	WatchedSeconds *int      `json:"watched_seconds" binding:"required,gte=0"`
 }
 
-func (uc *WatchHistoryUseCase) CreateOrUpdateWatchHistory(ctx context.Context, userID uuid.UUID, input WatchHistoryInput) (*domain.WatchHistory, error) {
-	content, err := uc.contentRepo.GetByID(ctx, input.ContentID)
+type SyncEvent struct {
+	ContentID      uuid.UUID `json:"content_id" binding:"required"`
+	WatchedSeconds *int      `json:"watched_seconds" binding:"required,gte=0"`
+	ClientTime     time.Time `json:"client_time" binding:"required"`
+}
+
+type SyncWatchHistoryInput struct {
+	DeviceID string      `json:"device_id" binding:"required,max=100"`
+	Events   []SyncEvent `json:"events" binding:"required,min=1,max=500,dive"`
+}
+
+type SyncEventStatus string
+
+const (
+	SyncEventApplied  SyncEventStatus = "applied"
+	SyncEventStale    SyncEventStatus = "stale"
+	SyncEventRejected SyncEventStatus = "rejected"
+)
+
+// SyncEventResult reports what happened to the event at Index of the request
+type SyncEventResult struct {
+	Index     int             `json:"index"`
+	ContentID uuid.UUID       `json:"content_id"`
+	Status    SyncEventStatus `json:"status"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type SyncResult struct {
+	Results   []SyncEventResult      `json:"results"`
+	Histories []*domain.WatchHistory `json:"histories"`
+}
+
+func (uc *WatchHistoryUseCase) CreateOrUpdateWatchHistory(ctx context.Context, userID uuid.UUID, country string, input WatchHistoryInput) (*domain.WatchHistory, error) {
+	// Access is checked on every heartbeat, a title may be trashed, leave its
+	// window or region, or the subscription lapse while it is being watched
+	content, err := uc.watchableContent(ctx, userID, country, input.ContentID)
 	if err != nil {
 		return nil, err
 	}
-	if content.AccessLevel != domain.AccessLevelFree {
-		subscription, err := uc.subscriptionRepo.GetActiveByUserID(ctx, userID)
-		if err != nil || subscription.IsExpired() {
-			return nil, domain.ErrContentNotAccessible
+	if uc.progressBuffer != nil {
+		buffered, err := uc.progressBuffer.Get(ctx, userID, input.ContentID)
+		if err != nil && err != domain.ErrWatchHistoryNotFound {
+			return nil, err
+		}
+		if buffered != nil {
+			return uc.recordProgress(ctx, buffered, content, country, *input.WatchedSeconds)
 		}
 	}
 	existingHistory, err := uc.watchHistoryRepo.GetByUserAndContent(ctx, userID, input.ContentID)
@@ -44,47 +100,263 @@ func (uc *WatchHistoryUseCase) CreateOrUpdateWatchHistory(ctx context.Context, u
 		return nil, err
 	}
 	if existingHistory != nil {
-		existingHistory.WatchedSeconds = *input.WatchedSeconds
-		existingHistory.LastWatchedAt = time.Now()
-		if existingHistory.ProgressPercentage() >= 90.0 {
-			existingHistory.Status = domain.WatchStatusCompleted
-		} else if *input.WatchedSeconds > 0 {
-			existingHistory.Status = domain.WatchStatusPaused
-		}
-		if err := uc.watchHistoryRepo.Update(ctx, existingHistory); err != nil {
-			return nil, err
-		}
-		existingHistory.Content = content
-		return existingHistory, nil
+		return uc.recordProgress(ctx, existingHistory, content, country, *input.WatchedSeconds)
 	}
 	watchHistory := &domain.WatchHistory{
-		ID:             uuid.New(),
-		UserID:         userID,
-		ContentID:      input.ContentID,
-		WatchedSeconds: *input.WatchedSeconds,
-		TotalSeconds:   content.DurationSeconds,
-		Status:         domain.WatchStatusStarted,
-		LastWatchedAt:  time.Now(),
-	}
-	if watchHistory.ProgressPercentage() >= 90.0 {
-		watchHistory.Status = domain.WatchStatusCompleted
+		ID:           uuid.New(),
+		UserID:       userID,
+		ContentID:    input.ContentID,
+		TotalSeconds: content.DurationSeconds,
+		Status:       domain.WatchStatusStarted,
+		Country:      country,
 	}
+	uc.applyProgress(watchHistory, content, *input.WatchedSeconds, time.Now())
 	if err := uc.watchHistoryRepo.Create(ctx, watchHistory); err != nil {
 		return nil, err
 	}
+	uc.removeFromWatchlistOnCompletion(ctx, watchHistory, "")
 	watchHistory.Content = content
 	return watchHistory, nil
 }
 
-func (uc *WatchHistoryUseCase) GetWatchHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.WatchHistory, int64, error) {
-	return uc.watchHistoryRepo.GetByUserID(ctx, userID, limit, offset)
+func (uc *WatchHistoryUseCase) recordProgress(ctx context.Context, history *domain.WatchHistory, content *domain.Content, country string, watchedSeconds int) (*domain.WatchHistory, error) {
+	previousStatus := history.Status
+	history.Country = country
+	uc.applyProgress(history, content, watchedSeconds, time.Now())
+	if err := uc.saveProgress(ctx, history, content); err != nil {
+		return nil, err
+	}
+	uc.removeFromWatchlistOnCompletion(ctx, history, previousStatus)
+	history.Content = content
+	return history, nil
+}
+
+// saveProgress updates an existing row, through the progress buffer when one
+// is configured. Buffered entries carry their content so continue watching
+// can list them before they are flushed.
+func (uc *WatchHistoryUseCase) saveProgress(ctx context.Context, history *domain.WatchHistory, content *domain.Content) error {
+	if uc.progressBuffer == nil {
+		return uc.watchHistoryRepo.Update(ctx, history)
+	}
+	history.Content = content
+	return uc.progressBuffer.Put(ctx, history)
+}
+
+// currentProgress prefers the buffered copy of a row, which is never older
+// than what is stored because every update goes through the buffer
+func (uc *WatchHistoryUseCase) currentProgress(ctx context.Context, userID, contentID uuid.UUID) (*domain.WatchHistory, error) {
+	if uc.progressBuffer != nil {
+		history, err := uc.progressBuffer.Get(ctx, userID, contentID)
+		if err != domain.ErrWatchHistoryNotFound {
+			return history, err
+		}
+	}
+	return uc.watchHistoryRepo.GetByUserAndContent(ctx, userID, contentID)
+}
+
+// SyncWatchHistory merges progress recorded offline. Events are replayed per
+// title in client time order and only win over stored progress last watched
+// earlier, so a stale upload never rewinds progress made on another device.
+func (uc *WatchHistoryUseCase) SyncWatchHistory(ctx context.Context, userID uuid.UUID, country string, input SyncWatchHistoryInput) (*SyncResult, error) {
+	now := time.Now()
+	var order []uuid.UUID
+	byContent := make(map[uuid.UUID][]int)
+	for i, event := range input.Events {
+		if _, ok := byContent[event.ContentID]; !ok {
+			order = append(order, event.ContentID)
+		}
+		byContent[event.ContentID] = append(byContent[event.ContentID], i)
+	}
+	result := &SyncResult{
+		Results:   make([]SyncEventResult, len(input.Events)),
+		Histories: make([]*domain.WatchHistory, 0, len(order)),
+	}
+	for _, contentID := range order {
+		indices := byContent[contentID]
+		sort.SliceStable(indices, func(a, b int) bool {
+			return input.Events[indices[a]].ClientTime.Before(input.Events[indices[b]].ClientTime)
+		})
+		history, err := uc.syncContent(ctx, userID, country, contentID, input, indices, now, result.Results)
+		if err != nil {
+			return nil, err
+		}
+		if history != nil {
+			result.Histories = append(result.Histories, history)
+		}
+	}
+	return result, nil
+}
+
+func (uc *WatchHistoryUseCase) syncContent(ctx context.Context, userID uuid.UUID, country string, contentID uuid.UUID, input SyncWatchHistoryInput, indices []int, now time.Time, results []SyncEventResult) (*domain.WatchHistory, error) {
+	settle := func(i int, status SyncEventStatus, err error) {
+		results[i] = SyncEventResult{Index: i, ContentID: contentID, Status: status}
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+	content, err := uc.watchableContent(ctx, userID, country, contentID)
+	switch err {
+	case nil:
+	case domain.ErrContentNotFound, domain.ErrContentNotAvailable, domain.ErrContentNotAvailableInRegion, domain.ErrContentNotAccessible:
+		for _, i := range indices {
+			settle(i, SyncEventRejected, err)
+		}
+		return nil, nil
+	default:
+		return nil, err
+	}
+	history, err := uc.currentProgress(ctx, userID, contentID)
+	if err != nil && err != domain.ErrWatchHistoryNotFound {
+		return nil, err
+	}
+	isNew := history == nil
+	if isNew {
+		history = &domain.WatchHistory{
+			ID:           uuid.New(),
+			UserID:       userID,
+			ContentID:    contentID,
+			TotalSeconds: content.DurationSeconds,
+			Status:       domain.WatchStatusStarted,
+		}
+	}
+	previousStatus := history.Status
+	applied := false
+	for _, i := range indices {
+		event := input.Events[i]
+		switch {
+		case event.ClientTime.After(now.Add(maxClientClockSkew)):
+			settle(i, SyncEventRejected, domain.ErrClientTimeInFuture)
+		case *event.WatchedSeconds > history.TotalSeconds:
+			settle(i, SyncEventRejected, domain.ErrProgressBeyondDuration)
+		case !event.ClientTime.After(history.LastWatchedAt):
+			settle(i, SyncEventStale, nil)
+		default:
+			uc.applyProgress(history, content, *event.WatchedSeconds, event.ClientTime.UTC())
+			history.DeviceID = input.DeviceID
+			applied = true
+			settle(i, SyncEventApplied, nil)
+		}
+	}
+	if !applied {
+		if isNew {
+			return nil, nil
+		}
+		history.Content = content
+		return history, nil
+	}
+	history.Country = country
+	if isNew {
+		err = uc.watchHistoryRepo.Create(ctx, history)
+	} else {
+		err = uc.saveProgress(ctx, history, content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	uc.removeFromWatchlistOnCompletion(ctx, history, previousStatus)
+	history.Content = content
+	return history, nil
+}
+
+// watchableContent loads content the user may record progress for
+func (uc *WatchHistoryUseCase) watchableContent(ctx context.Context, userID uuid.UUID, country string, contentID uuid.UUID) (*domain.Content, error) {
+	content, err := uc.contentRepo.GetByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !content.IsWithinAvailabilityWindow(time.Now()) {
+		return nil, domain.ErrContentNotAvailable
+	}
+	if !content.IsAvailableInCountry(country) {
+		return nil, domain.ErrContentNotAvailableInRegion
+	}
+	if content.AccessLevel != domain.AccessLevelFree {
+		subscription, err := uc.subscriptionRepo.GetActiveByUserID(ctx, userID)
+		if err != nil || subscription.IsExpired() {
+			return nil, domain.ErrContentNotAccessible
+		}
+	}
+	return content, nil
+}
+
+func (uc *WatchHistoryUseCase) GetWatchHistory(ctx context.Context, userID uuid.UUID, page repositories.Page) ([]*domain.WatchHistory, int64, *repositories.Keyset, error) {
+	page, err := normalizePage(page, WatchHistorySortKeys)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	histories, total, err := uc.watchHistoryRepo.GetByUserID(ctx, userID, lookahead(page))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	histories, next := trimPage(histories, page.Limit, watchHistoryKeyset(page.Sort))
+	for _, history := range histories {
+		markUnavailable(history)
+	}
+	return histories, total, next, nil
+}
+
+// markUnavailable keeps trashed titles in a viewer's history but reduces them
+// to what identifies them, flagged as no longer available
+func markUnavailable(history *domain.WatchHistory) {
+	if history.Content == nil || !history.Content.IsDeleted() {
+		return
+	}
+	history.ContentUnavailable = true
+	history.Content = &domain.Content{
+		ID:           history.Content.ID,
+		Title:        history.Content.Title,
+		ThumbnailURL: history.Content.ThumbnailURL,
+		DeletedAt:    history.Content.DeletedAt,
+	}
 }
 
 func (uc *WatchHistoryUseCase) GetContinueWatching(ctx context.Context, userID uuid.UUID) ([]*domain.WatchHistory, error) {
-	return uc.watchHistoryRepo.GetContinueWatching(ctx, userID, 10)
+	histories, err := uc.watchHistoryRepo.GetContinueWatching(ctx, userID, continueWatchingLimit)
+	if err != nil || uc.progressBuffer == nil {
+		return histories, err
+	}
+	buffered, err := uc.progressBuffer.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mergeContinueWatching(histories, buffered), nil
 }
 
-func (uc *WatchHistoryUseCase) UpdateProgress(ctx context.Context, userID, historyID uuid.UUID, watchedSeconds int) (*domain.WatchHistory, error) {
+// mergeContinueWatching overlays buffered progress on the stored rows. A
+// buffered entry may finish a title or bring back one the stored rows left out.
+func mergeContinueWatching(stored, buffered []*domain.WatchHistory) []*domain.WatchHistory {
+	byContent := make(map[uuid.UUID]*domain.WatchHistory, len(stored)+len(buffered))
+	for _, history := range stored {
+		byContent[history.ContentID] = history
+	}
+	for _, history := range buffered {
+		if existing, ok := byContent[history.ContentID]; ok && existing.LastWatchedAt.After(history.LastWatchedAt) {
+			continue
+		}
+		byContent[history.ContentID] = history
+	}
+	merged := make([]*domain.WatchHistory, 0, len(byContent))
+	for _, history := range byContent {
+		if history.Status == domain.WatchStatusCompleted || history.WatchedSeconds == 0 {
+			continue
+		}
+		if history.Content == nil || history.Content.IsDeleted() {
+			continue
+		}
+		merged = append(merged, history)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].LastWatchedAt.After(merged[j].LastWatchedAt)
+	})
+	if len(merged) > continueWatchingLimit {
+		merged = merged[:continueWatchingLimit]
+	}
+	return merged
+}
+
+// UpdateProgress moves the position of one of the user's histories. Access is
+// checked as on every heartbeat.
+func (uc *WatchHistoryUseCase) UpdateProgress(ctx context.Context, userID, historyID uuid.UUID, country string, watchedSeconds int) (*domain.WatchHistory, error) {
 	history, err := uc.watchHistoryRepo.GetByID(ctx, historyID)
 	if err != nil {
 		return nil, err
@@ -92,15 +364,64 @@ func (uc *WatchHistoryUseCase) UpdateProgress(ctx context.Context, userID, histo
 	if history.UserID != userID {
 		return nil, domain.ErrForbidden
 	}
-	history.WatchedSeconds = watchedSeconds
-	history.LastWatchedAt = time.Now()
-	if history.ProgressPercentage() >= 90.0 {
-		history.Status = domain.WatchStatusCompleted
-	} else {
-		history.Status = domain.WatchStatusPaused
+	if history.Content != nil && history.Content.IsDeleted() {
+		return nil, domain.ErrContentNotAvailable
+	}
+	content, err := uc.watchableContent(ctx, userID, country, history.ContentID)
+	if err != nil {
+		return nil, err
 	}
-	if err := uc.watchHistoryRepo.Update(ctx, history); err != nil {
+	if uc.progressBuffer != nil {
+		buffered, err := uc.progressBuffer.Get(ctx, userID, history.ContentID)
+		if err != nil && err != domain.ErrWatchHistoryNotFound {
+			return nil, err
+		}
+		if buffered != nil {
+			history = buffered
+		}
+	}
+	previousStatus := history.Status
+	history.Country = country
+	uc.applyProgress(history, content, watchedSeconds, time.Now())
+	if err := uc.saveProgress(ctx, history, content); err != nil {
 		return nil, err
 	}
+	uc.removeFromWatchlistOnCompletion(ctx, history, previousStatus)
+	history.Content = content
 	return history, nil
 }
+
+// applyProgress moves the position and derives the status from the completion
+// policy of the content's type. Restarting a completed title begins a rewatch.
+func (uc *WatchHistoryUseCase) applyProgress(history *domain.WatchHistory, content *domain.Content, watchedSeconds int, now time.Time) {
+	policy := uc.completion.Default
+	if content != nil {
+		policy = uc.completion.For(content.ContentType)
+	}
+	wasCompleted := history.Status == domain.WatchStatusCompleted
+	history.WatchedSeconds = watchedSeconds
+	history.LastWatchedAt = now
+	switch {
+	case history.ReachedCompletionPoint(policy, content):
+		if !wasCompleted {
+			history.Status = domain.WatchStatusCompleted
+			history.CompletedAt = &now
+		}
+	case wasCompleted && watchedSeconds <= rewatchStartSeconds:
+		history.Status = domain.WatchStatusStarted
+		history.RewatchCount++
+	case watchedSeconds > 0:
+		history.Status = domain.WatchStatusPaused
+	}
+}
+
+// removeFromWatchlistOnCompletion drops a title from the watchlist the first
+// time it is finished. The progress is already saved, so failures are only logged.
+func (uc *WatchHistoryUseCase) removeFromWatchlistOnCompletion(ctx context.Context, history *domain.WatchHistory, previousStatus domain.WatchStatus) {
+	if history.Status != domain.WatchStatusCompleted || previousStatus == domain.WatchStatusCompleted {
+		return
+	}
+	if err := uc.watchlistRepo.Remove(ctx, history.UserID, history.ContentID); err != nil && err != domain.ErrWatchlistItemNotFound {
+		log.Printf("remove completed content %s from watchlist of user %s: %v", history.ContentID, history.UserID, err)
+	}
+}
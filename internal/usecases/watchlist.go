@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/google/uuid"
+)
+
+type WatchlistUseCase struct {
+	watchlistRepo    repositories.WatchlistRepository
+	contentRepo      repositories.ContentRepository
+	watchHistoryRepo repositories.WatchHistoryRepository
+}
+
+func NewWatchlistUseCase(watchlistRepo repositories.WatchlistRepository, contentRepo repositories.ContentRepository, watchHistoryRepo repositories.WatchHistoryRepository) *WatchlistUseCase {
+	return &WatchlistUseCase{
+		watchlistRepo:    watchlistRepo,
+		contentRepo:      contentRepo,
+		watchHistoryRepo: watchHistoryRepo,
+	}
+}
+
+type AddToWatchlistInput struct {
+	ContentID uuid.UUID `json:"content_id" binding:"required"`
+}
+
+type ReorderWatchlistInput struct {
+	ContentIDs []uuid.UUID `json:"content_ids" binding:"required"`
+}
+
+type WatchProgress struct {
+	WatchedSeconds     int                `json:"watched_seconds"`
+	TotalSeconds       int                `json:"total_seconds"`
+	ProgressPercentage float64            `json:"progress_percentage"`
+	Status             domain.WatchStatus `json:"status"`
+	LastWatchedAt      time.Time          `json:"last_watched_at"`
+}
+
+func newWatchProgress(history *domain.WatchHistory) *WatchProgress {
+	return &WatchProgress{
+		WatchedSeconds:     history.WatchedSeconds,
+		TotalSeconds:       history.TotalSeconds,
+		ProgressPercentage: history.ProgressPercentage(),
+		Status:             history.Status,
+		LastWatchedAt:      history.LastWatchedAt,
+	}
+}
+
+type WatchlistEntry struct {
+	Position int             `json:"position"`
+	AddedAt  time.Time       `json:"added_at"`
+	Content  *domain.Content `json:"content"`
+	Progress *WatchProgress  `json:"progress,omitempty"`
+}
+
+func (uc *WatchlistUseCase) AddToWatchlist(ctx context.Context, userID uuid.UUID, country string, input AddToWatchlistInput) (*domain.WatchlistItem, error) {
+	content, err := uc.contentRepo.GetByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if !content.Published {
+		return nil, domain.ErrContentNotPublished
+	}
+	if !content.IsAvailableInCountry(country) {
+		return nil, domain.ErrContentNotAvailableInRegion
+	}
+	exists, err := uc.watchlistRepo.Exists(ctx, userID, input.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrWatchlistItemExists
+	}
+	item := &domain.WatchlistItem{ID: uuid.New(), UserID: userID, ContentID: input.ContentID}
+	if err := uc.watchlistRepo.Add(ctx, item); err != nil {
+		return nil, err
+	}
+	item.Content = content
+	return item, nil
+}
+
+func (uc *WatchlistUseCase) RemoveFromWatchlist(ctx context.Context, userID, contentID uuid.UUID) error {
+	return uc.watchlistRepo.Remove(ctx, userID, contentID)
+}
+
+// ReorderWatchlist takes the complete new order, partial lists are rejected
+// so positions never collide
+func (uc *WatchlistUseCase) ReorderWatchlist(ctx context.Context, userID uuid.UUID, input ReorderWatchlistInput) error {
+	items, err := uc.watchlistRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(input.ContentIDs) != len(items) {
+		return domain.ErrInvalidWatchlistOrder
+	}
+	remaining := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		remaining[item.ContentID] = true
+	}
+	for _, contentID := range input.ContentIDs {
+		if !remaining[contentID] {
+			return domain.ErrInvalidWatchlistOrder
+		}
+		delete(remaining, contentID)
+	}
+	return uc.watchlistRepo.Reorder(ctx, userID, input.ContentIDs)
+}
+
+// GetWatchlist returns the list in the user's order with any playback progress
+// merged in. Titles that are unpublished, outside their window or blocked in
+// the viewer's region stay saved but are hidden until they come back.
+func (uc *WatchlistUseCase) GetWatchlist(ctx context.Context, userID uuid.UUID, country string) ([]*WatchlistEntry, error) {
+	items, err := uc.watchlistRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	entries := make([]*WatchlistEntry, 0, len(items))
+	contentIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if item.Content == nil || !item.Content.IsAvailableAt(now) || !item.Content.IsAvailableInCountry(country) {
+			continue
+		}
+		entries = append(entries, &WatchlistEntry{Position: item.Position, AddedAt: item.CreatedAt, Content: item.Content})
+		contentIDs = append(contentIDs, item.ContentID)
+	}
+	histories, err := uc.watchHistoryRepo.GetByUserAndContentIDs(ctx, userID, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+	progress := make(map[uuid.UUID]*WatchProgress, len(histories))
+	for _, history := range histories {
+		progress[history.ContentID] = newWatchProgress(history)
+	}
+	for _, entry := range entries {
+		entry.Progress = progress[entry.Content.ID]
+	}
+	return entries, nil
+}
@@ -0,0 +1,109 @@
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHash encodes src as a BlurHash (https://blurha.sh) string with the given
+// number of horizontal and vertical components, each between 1 and 9
+func BlurHash(src image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9")
+	}
+	// The hash only carries low frequencies, so a small copy is enough
+	img := Resize(src, 32)
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width == 0 || height == 0 {
+		return "", fmt.Errorf("blurhash needs a non-empty image")
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1.0
+			}
+			var r, g, b float64
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+					o := img.PixOffset(x, y)
+					r += basis * sRGBToLinear(img.Pix[o])
+					g += basis * sRGBToLinear(img.Pix[o+1])
+					b += basis * sRGBToLinear(img.Pix[o+2])
+				}
+			}
+			scale := 1.0 / float64(width*height)
+			factors = append(factors, [3]float64{r * scale, g * scale, b * scale})
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	maximumValue := 1.0
+	if len(factors) > 1 {
+		actualMax := 0.0
+		for _, factor := range factors[1:] {
+			for _, v := range factor {
+				actualMax = math.Max(actualMax, math.Abs(v))
+			}
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantisedMax+1) / 166
+		hash.WriteString(encode83(quantisedMax, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	dc := factors[0]
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4))
+	for _, factor := range factors[1:] {
+		hash.WriteString(encode83(encodeAC(factor, maximumValue), 2))
+	}
+	return hash.String(), nil
+}
+
+func encodeAC(factor [3]float64, maximumValue float64) int {
+	quant := func(v float64) int {
+		return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+	}
+	return quant(factor[0])*19*19 + quant(factor[1])*19 + quant(factor[2])
+}
+
+func encode83(value, length int) string {
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		out[i] = base83Chars[value%83]
+		value /= 83
+	}
+	return string(out)
+}
+
+func sRGBToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
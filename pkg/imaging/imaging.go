@@ -0,0 +1,130 @@
+// Package imaging produces downscaled image variants and compact placeholders
+// (dominant color and BlurHash) using only the standard library.
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Resize downscales src to the given width keeping its aspect ratio, using
+// area averaging so thin details are blended rather than skipped. Widths at
+// or above the source width return a copy at the original size.
+func Resize(src image.Image, width int) *image.RGBA {
+	rgba := toRGBA(src)
+	srcW, srcH := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	if width <= 0 || width >= srcW {
+		return rgba
+	}
+	height := int(math.Round(float64(srcH) * float64(width) / float64(srcW)))
+	if height < 1 {
+		height = 1
+	}
+	return resampleVertical(resampleHorizontal(rgba, width), height)
+}
+
+// DominantColor returns the most common color as "#rrggbb", bucketing
+// channels to 4 bits and averaging the pixels of the winning bucket
+func DominantColor(src image.Image) string {
+	small := Resize(src, 64)
+	type bucket struct{ r, g, b, n int }
+	buckets := make(map[int]*bucket)
+	var best *bucket
+	bounds := small.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			i := small.PixOffset(x, y)
+			r, g, b, a := int(small.Pix[i]), int(small.Pix[i+1]), int(small.Pix[i+2]), int(small.Pix[i+3])
+			if a < 128 {
+				continue
+			}
+			key := (r>>4)<<8 | (g>>4)<<4 | b>>4
+			bk := buckets[key]
+			if bk == nil {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.r, bk.g, bk.b, bk.n = bk.r+r, bk.g+g, bk.b+b, bk.n+1
+			if best == nil || bk.n > best.n {
+				best = bk
+			}
+		}
+	}
+	if best == nil {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.n, best.g/best.n, best.b/best.n)
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	return rgba
+}
+
+// resampleHorizontal averages each destination column over its fractional
+// span of source columns, weighting partially covered columns by coverage
+func resampleHorizontal(src *image.RGBA, width int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, srcH))
+	scale := float64(srcW) / float64(width)
+	for dx := 0; dx < width; dx++ {
+		start, end := float64(dx)*scale, float64(dx+1)*scale
+		for y := 0; y < srcH; y++ {
+			var acc [4]float64
+			for sx := int(start); sx < srcW && float64(sx) < end; sx++ {
+				weight := math.Min(end, float64(sx+1)) - math.Max(start, float64(sx))
+				i := src.PixOffset(sx, y)
+				for c := 0; c < 4; c++ {
+					acc[c] += float64(src.Pix[i+c]) * weight
+				}
+			}
+			o := dst.PixOffset(dx, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[o+c] = clampByte(acc[c] / scale)
+			}
+		}
+	}
+	return dst
+}
+
+func resampleVertical(src *image.RGBA, height int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, srcW, height))
+	scale := float64(srcH) / float64(height)
+	for dy := 0; dy < height; dy++ {
+		start, end := float64(dy)*scale, float64(dy+1)*scale
+		for x := 0; x < srcW; x++ {
+			var acc [4]float64
+			for sy := int(start); sy < srcH && float64(sy) < end; sy++ {
+				weight := math.Min(end, float64(sy+1)) - math.Max(start, float64(sy))
+				i := src.PixOffset(x, sy)
+				for c := 0; c < 4; c++ {
+					acc[c] += float64(src.Pix[i+c]) * weight
+				}
+			}
+			o := dst.PixOffset(x, dy)
+			for c := 0; c < 4; c++ {
+				dst.Pix[o+c] = clampByte(acc[c] / scale)
+			}
+		}
+	}
+	return dst
+}
+
+func clampByte(v float64) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
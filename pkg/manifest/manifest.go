@@ -0,0 +1,121 @@
+// Package manifest renders adaptive streaming master manifests (HLS master
+// playlists and DASH MPDs) from a list of already-resolved variants.
+//
+// It performs no entitlement checks or URL signing; callers pass only the
+// variants a viewer may play, with their final URIs.
+package manifest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	HLSContentType  = "application/vnd.apple.mpegurl"
+	DASHContentType = "application/dash+xml"
+)
+
+type Variant struct {
+	ID            string
+	BandwidthKbps int
+	Width         int
+	Height        int
+	Codecs        string
+	URI           string
+}
+
+// HLS renders an HLS master playlist with one EXT-X-STREAM-INF per variant,
+// in the order given
+func HLS(variants []Variant) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	buf.WriteString("#EXT-X-VERSION:3\n")
+	buf.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+	for _, v := range variants {
+		attrs := []string{fmt.Sprintf("BANDWIDTH=%d", v.BandwidthKbps*1000)}
+		if v.Width > 0 && v.Height > 0 {
+			attrs = append(attrs, fmt.Sprintf("RESOLUTION=%dx%d", v.Width, v.Height))
+		}
+		if v.Codecs != "" {
+			attrs = append(attrs, fmt.Sprintf("CODECS=%q", v.Codecs))
+		}
+		buf.WriteString("#EXT-X-STREAM-INF:" + strings.Join(attrs, ",") + "\n")
+		buf.WriteString(v.URI + "\n")
+	}
+	return buf.Bytes()
+}
+
+type mpd struct {
+	XMLName                   xml.Name `xml:"MPD"`
+	Namespace                 string   `xml:"xmlns,attr"`
+	Profiles                  string   `xml:"profiles,attr"`
+	Type                      string   `xml:"type,attr"`
+	MediaPresentationDuration string   `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime             string   `xml:"minBufferTime,attr"`
+	Period                    period   `xml:"Period"`
+}
+
+type period struct {
+	ID            string        `xml:"id,attr"`
+	AdaptationSet adaptationSet `xml:"AdaptationSet"`
+}
+
+type adaptationSet struct {
+	ContentType        string           `xml:"contentType,attr"`
+	SegmentAlignment   bool             `xml:"segmentAlignment,attr"`
+	BitstreamSwitching bool             `xml:"bitstreamSwitching,attr"`
+	Representations    []representation `xml:"Representation"`
+}
+
+type representation struct {
+	ID        string `xml:"id,attr"`
+	Bandwidth int    `xml:"bandwidth,attr"`
+	Width     int    `xml:"width,attr,omitempty"`
+	Height    int    `xml:"height,attr,omitempty"`
+	Codecs    string `xml:"codecs,attr,omitempty"`
+	BaseURL   string `xml:"BaseURL"`
+}
+
+// DASH renders a static on-demand MPD with a single video adaptation set,
+// each variant becoming a Representation addressed by its BaseURL
+func DASH(variants []Variant, duration time.Duration) ([]byte, error) {
+	doc := mpd{
+		Namespace:                 "urn:mpeg:dash:schema:mpd:2011",
+		Profiles:                  "urn:mpeg:dash:profile:isoff-on-demand:2011",
+		Type:                      "static",
+		MediaPresentationDuration: isoDuration(duration),
+		MinBufferTime:             "PT2S",
+		Period: period{
+			ID: "0",
+			AdaptationSet: adaptationSet{
+				ContentType:        "video",
+				SegmentAlignment:   true,
+				BitstreamSwitching: true,
+			},
+		},
+	}
+	for _, v := range variants {
+		doc.Period.AdaptationSet.Representations = append(doc.Period.AdaptationSet.Representations, representation{
+			ID:        v.ID,
+			Bandwidth: v.BandwidthKbps * 1000,
+			Width:     v.Width,
+			Height:    v.Height,
+			Codecs:    v.Codecs,
+			BaseURL:   v.URI,
+		})
+	}
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// isoDuration formats d as an ISO 8601 duration truncated to whole seconds
+func isoDuration(d time.Duration) string {
+	total := int(d / time.Second)
+	return fmt.Sprintf("PT%dH%dM%dS", total/3600, total%3600/60, total%60)
+}
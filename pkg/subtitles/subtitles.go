@@ -0,0 +1,82 @@
+// Package subtitles converts and validates text subtitle files for web players.
+package subtitles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrMalformed = errors.New("subtitles: malformed subtitle file")
+
+var (
+	srtTiming = regexp.MustCompile(`^(\d{1,2}):(\d{2}):(\d{2})[,.](\d{3})\s*-->\s*(\d{1,2}):(\d{2}):(\d{2})[,.](\d{3})`)
+	fontTag   = regexp.MustCompile(`(?i)</?font[^>]*>`)
+)
+
+// SRTToWebVTT converts a SubRip file into WebVTT, keeping cue numbers as cue
+// identifiers and dropping SRT-only markup such as <font> tags and cue
+// coordinates. Cues are emitted in file order.
+func SRTToWebVTT(data []byte) ([]byte, error) {
+	blocks := strings.Split(normalize(data), "\n\n")
+
+	var out bytes.Buffer
+	out.WriteString("WEBVTT\n")
+	cues := 0
+	for _, block := range blocks {
+		block = strings.Trim(block, "\n")
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		lines := strings.Split(block, "\n")
+		id := ""
+		if _, err := strconv.Atoi(strings.TrimSpace(lines[0])); err == nil {
+			id = strings.TrimSpace(lines[0])
+			lines = lines[1:]
+		}
+		if len(lines) == 0 {
+			return nil, ErrMalformed
+		}
+		m := srtTiming.FindStringSubmatch(strings.TrimSpace(lines[0]))
+		if m == nil {
+			return nil, fmt.Errorf("%w: bad timing line %q", ErrMalformed, lines[0])
+		}
+		out.WriteString("\n")
+		if id != "" {
+			out.WriteString(id + "\n")
+		}
+		fmt.Fprintf(&out, "%s --> %s\n", timestamp(m[1:5]), timestamp(m[5:9]))
+		for _, line := range lines[1:] {
+			line = fontTag.ReplaceAllString(line, "")
+			out.WriteString(strings.ReplaceAll(line, "-->", "--&gt;") + "\n")
+		}
+		cues++
+	}
+	if cues == 0 {
+		return nil, ErrMalformed
+	}
+	return out.Bytes(), nil
+}
+
+// ValidateWebVTT checks the mandatory WEBVTT signature line
+func ValidateWebVTT(data []byte) error {
+	text := normalize(data)
+	if text != "WEBVTT" && !strings.HasPrefix(text, "WEBVTT\n") && !strings.HasPrefix(text, "WEBVTT ") && !strings.HasPrefix(text, "WEBVTT\t") {
+		return ErrMalformed
+	}
+	return nil
+}
+
+func normalize(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
+
+func timestamp(parts []string) string {
+	hours, _ := strconv.Atoi(parts[0])
+	return fmt.Sprintf("%02d:%s:%s.%s", hours, parts[1], parts[2], parts[3])
+}
@@ -0,0 +1,246 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) FailUnfinished(ctx context.Context, message string, staleBefore, at time.Time) (int64, error) {
+	args := m.Called(ctx, message, staleBefore, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestParseCatalog_CSV(t *testing.T) {
+	data := "\ufeffexternal_id,title,access_level,duration_seconds,published,allowed_countries\n" +
+		"lic-1,Heat,premium,10200,true,\"US,CA\"\n" +
+		"lic-2,Ronin,basic,ninety,false,\n" +
+		"lic-3,Short\n"
+
+	rows, err := usecases.ParseCatalog(domain.CatalogFormatCSV, []byte(data))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "lic-1", rows[0].Record.ExternalID)
+	assert.Equal(t, 10200, rows[0].Record.DurationSeconds)
+	assert.True(t, rows[0].Record.Published)
+	assert.Equal(t, []string{"US", "CA"}, rows[0].Record.AllowedCountries)
+	assert.EqualError(t, rows[1].Err, "duration_seconds must be a whole number")
+	assert.Equal(t, 3, rows[2].Number)
+	assert.Error(t, rows[2].Err)
+}
+
+func TestParseCatalog_RejectsBadFiles(t *testing.T) {
+	for name, tc := range map[string]struct {
+		format domain.CatalogFormat
+		data   string
+		err    error
+	}{
+		"unknown column":   {domain.CatalogFormatCSV, "external_id,title,access_level,duration_seconds,rating\n", domain.ErrInvalidImportFile},
+		"missing required": {domain.CatalogFormatCSV, "external_id,title\nx,y\n", domain.ErrInvalidImportFile},
+		"no rows":          {domain.CatalogFormatJSONL, "\n\n", domain.ErrInvalidImportFile},
+		"unknown format":   {domain.CatalogFormat("xml"), "<catalog/>", domain.ErrInvalidCatalogFormat},
+	} {
+		_, err := usecases.ParseCatalog(tc.format, []byte(tc.data))
+		assert.Equal(t, tc.err, err, name)
+	}
+}
+
+func TestParseCatalog_JSONL(t *testing.T) {
+	data := `{"external_id":"lic-1","title":"Heat","access_level":"premium","duration_seconds":10200}
+
+not json
+`
+	rows, err := usecases.ParseCatalog(domain.CatalogFormatJSONL, []byte(data))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "Heat", rows[0].Record.Title)
+	assert.Equal(t, 3, rows[1].Number)
+	assert.Error(t, rows[1].Err)
+}
+
+func TestRunImport_DryRunClassifiesRowsWithoutWriting(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	mockJobRepo := new(MockImportJobRepository)
+
+	unchanged := &domain.Content{ID: uuid.New(), Title: "Same", AccessLevel: domain.AccessLevelFree, DurationSeconds: 60}
+	changed := &domain.Content{ID: uuid.New(), Title: "Old", AccessLevel: domain.AccessLevelFree, DurationSeconds: 60}
+	mockContentRepo.On("GetByExternalID", mock.Anything, "new").Return(nil, domain.ErrContentNotFound)
+	mockContentRepo.On("GetByExternalID", mock.Anything, "same").Return(unchanged, nil)
+	mockContentRepo.On("GetByExternalID", mock.Anything, "changed").Return(changed, nil)
+	mockJobRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	record := func(externalID, title string) usecases.CatalogRecord {
+		r := usecases.CatalogRecord{ExternalID: externalID}
+		r.Title = title
+		r.AccessLevel = domain.AccessLevelFree
+		r.DurationSeconds = 60
+		return r
+	}
+	rows := []usecases.CatalogRow{
+		{Number: 1, Record: record("new", "Fresh")},
+		{Number: 2, Record: record("same", "Same")},
+		{Number: 3, Record: record("changed", "New")},
+		{Number: 4, Record: record("new", "Again")},
+		{Number: 5, Record: record("", "No ID")},
+	}
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	uc := usecases.NewCatalogUseCase(contentUseCase, mockContentRepo, mockJobRepo)
+	job := &domain.ImportJob{ID: uuid.New(), DryRun: true, TotalRows: len(rows)}
+	uc.RunImport(context.Background(), job, rows)
+
+	assert.Equal(t, domain.ImportStatusCompleted, job.Status)
+	assert.Equal(t, 5, job.Processed)
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Updated)
+	assert.Equal(t, 1, job.Unchanged)
+	assert.Equal(t, 2, job.Failed)
+	assert.Equal(t, domain.ImportRowError{Row: 4, ExternalID: "new", Error: "external_id already used on row 1"}, job.Errors[0])
+	assert.Equal(t, 5, job.Errors[1].Row)
+	assert.Equal(t, "Old", changed.Title)
+	mockRevisionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunImport_CreatesWithExternalIDAndRejectsTrashed(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	mockJobRepo := new(MockImportJobRepository)
+
+	trashed := &domain.Content{ID: uuid.New()}
+	trashed.DeletedAt.Valid = true
+	mockContentRepo.On("GetByExternalID", mock.Anything, "lic-1").Return(nil, domain.ErrContentNotFound)
+	mockContentRepo.On("GetByExternalID", mock.Anything, "lic-2").Return(trashed, nil)
+	mockRevisionRepo.On("Record", mock.Anything, mock.MatchedBy(func(content *domain.Content) bool {
+		return content.ExternalID != nil && *content.ExternalID == "lic-1" && content.Title == "Heat"
+	}), mock.AnythingOfType("*domain.ContentRevision"), false).Return(nil)
+	mockJobRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	rows, err := usecases.ParseCatalog(domain.CatalogFormatCSV, []byte("external_id,title,access_level,duration_seconds\nlic-1,Heat,premium,10200\nlic-2,Gone,free,60\n"))
+	assert.NoError(t, err)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	uc := usecases.NewCatalogUseCase(contentUseCase, mockContentRepo, mockJobRepo)
+	job := &domain.ImportJob{ID: uuid.New(), AuthorID: uuid.New()}
+	uc.RunImport(context.Background(), job, rows)
+
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, "content with this external_id is in the trash", job.Errors[0].Error)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestExportCatalog_CSVRoundTripsThroughImport(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	externalID := "lic-9"
+	contents := []*domain.Content{{
+		ID:               uuid.New(),
+		ExternalID:       &externalID,
+		Title:            "Heat, Director's Cut",
+		AccessLevel:      domain.AccessLevelPremium,
+		DurationSeconds:  10200,
+		Published:        true,
+		AllowedCountries: domain.CountryCodes{"US", "CA"},
+	}}
+	mockContentRepo.On("List", mock.Anything, repositories.ContentFilter{}, repositories.ContentScope{}, mock.Anything).Return(contents, int64(1), nil)
+
+	uc := usecases.NewCatalogUseCase(nil, mockContentRepo, new(MockImportJobRepository))
+	var out bytes.Buffer
+	err := uc.ExportCatalog(context.Background(), domain.CatalogFormatCSV, &out)
+	assert.NoError(t, err)
+
+	rows, err := usecases.ParseCatalog(domain.CatalogFormatCSV, out.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "lic-9", rows[0].Record.ExternalID)
+	assert.Equal(t, "Heat, Director's Cut", rows[0].Record.Title)
+	assert.Equal(t, []string{"US", "CA"}, rows[0].Record.AllowedCountries)
+}
+
+func TestRunImport_MarksJobFailedOnPanic(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockJobRepo := new(MockImportJobRepository)
+	mockJobRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	rows, err := usecases.ParseCatalog(domain.CatalogFormatCSV, []byte("external_id,title,access_level,duration_seconds\nlic-1,Heat,premium,10200\n"))
+	assert.NoError(t, err)
+
+	// GetByExternalID has no expectation, so the mock panics mid import
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	uc := usecases.NewCatalogUseCase(contentUseCase, mockContentRepo, mockJobRepo)
+	job := &domain.ImportJob{ID: uuid.New(), TotalRows: len(rows)}
+	assert.NotPanics(t, func() { uc.RunImport(context.Background(), job, rows) })
+
+	assert.Equal(t, domain.ImportStatusFailed, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, "import aborted after 0 rows by an internal error", job.Message)
+}
+
+func TestStartImport_WaitForImportsTracksBackgroundRun(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockJobRepo := new(MockImportJobRepository)
+	mockJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockJobRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockContentRepo.On("GetByExternalID", mock.Anything, "lic-1").Return(nil, domain.ErrContentNotFound)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	uc := usecases.NewCatalogUseCase(contentUseCase, mockContentRepo, mockJobRepo)
+	queued, err := uc.StartImport(context.Background(), uuid.New(), domain.CatalogFormatCSV, []byte("external_id,title,access_level,duration_seconds\nlic-1,Heat,premium,10200\n"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportStatusPending, queued.Status)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, uc.WaitForImports(ctx))
+	mockJobRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(job *domain.ImportJob) bool {
+		return job.Status == domain.ImportStatusCompleted && job.Created == 1
+	}))
+}
+
+func TestFailInterruptedImports(t *testing.T) {
+	mockJobRepo := new(MockImportJobRepository)
+	now := time.Now()
+	// jobs saved within the last few minutes may still be running on another instance
+	mockJobRepo.On("FailUnfinished", mock.Anything, mock.AnythingOfType("string"), now.Add(-5*time.Minute), now).Return(int64(2), nil)
+
+	uc := usecases.NewCatalogUseCase(nil, new(MockContentRepository), mockJobRepo)
+	failed, err := uc.FailInterruptedImports(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), failed)
+	mockJobRepo.AssertExpectations(t)
+}
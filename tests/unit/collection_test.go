@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) Create(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) List(ctx context.Context) ([]*domain.Collection, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) ListVisible(ctx context.Context, at time.Time) ([]*domain.Collection, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) Update(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) SetItems(ctx context.Context, collectionID uuid.UUID, contentIDs []uuid.UUID) error {
+	args := m.Called(ctx, collectionID, contentIDs)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateCollection_DefaultsToFreeAndStoresOrder(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockContentRepo := new(MockContentRepository)
+	first, second := uuid.New(), uuid.New()
+
+	mockContentRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Content{}, nil)
+	mockCollectionRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Collection) bool {
+		return c.AccessLevel == domain.AccessLevelFree && c.Title == "Staff Picks"
+	})).Return(nil)
+	mockCollectionRepo.On("SetItems", mock.Anything, mock.Anything, []uuid.UUID{second, first}).Return(nil)
+	mockCollectionRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Collection{Title: "Staff Picks"}, nil)
+
+	uc := usecases.NewCollectionUseCase(mockCollectionRepo, mockContentRepo)
+	collection, err := uc.CreateCollection(context.Background(), usecases.CollectionInput{
+		Title: "Staff Picks", ContentIDs: []uuid.UUID{second, first},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Staff Picks", collection.Title)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestCreateCollection_RejectsInvalidInput(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	uc := usecases.NewCollectionUseCase(new(MockCollectionRepository), mockContentRepo)
+	from := time.Now()
+	until := from.Add(-time.Hour)
+
+	_, err := uc.CreateCollection(context.Background(), usecases.CollectionInput{Title: "x", VisibleFrom: &from, VisibleUntil: &until})
+	assert.Equal(t, domain.ErrInvalidVisibility, err)
+
+	id := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, id).Return(&domain.Content{}, nil)
+	_, err = uc.CreateCollection(context.Background(), usecases.CollectionInput{Title: "x", ContentIDs: []uuid.UUID{id, id}})
+	assert.Equal(t, domain.ErrInvalidInput, err)
+
+	missing := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, missing).Return(nil, domain.ErrContentNotFound)
+	_, err = uc.CreateCollection(context.Background(), usecases.CollectionInput{Title: "x", ContentIDs: []uuid.UUID{missing}})
+	assert.Equal(t, domain.ErrContentNotFound, err)
+}
+
+func TestCollection_IsVisibleAt(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	collection := &domain.Collection{VisibleFrom: &now, VisibleUntil: &later}
+
+	assert.False(t, collection.IsVisibleAt(now.Add(-time.Minute)))
+	assert.True(t, collection.IsVisibleAt(now))
+	assert.False(t, collection.IsVisibleAt(later))
+}
+
+func TestGetHome_AssemblesRailsInOrder(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockTrendingRepo := new(MockTrendingRepository)
+	mockCache := new(MockCache)
+	userID := uuid.New()
+
+	started := &domain.Content{ID: uuid.New(), Published: true}
+	picked := &domain.Content{ID: uuid.New(), Published: true}
+	hidden := &domain.Content{ID: uuid.New()}
+	hot := &domain.Content{ID: uuid.New(), Published: true}
+	scary := &domain.Content{ID: uuid.New(), Published: true, Genre: "horror"}
+
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockWatchRepo.On("GetContinueWatching", mock.Anything, userID, mock.Anything).Return([]*domain.WatchHistory{
+		{ContentID: started.ID, Content: started, WatchedSeconds: 30, TotalSeconds: 60},
+	}, nil)
+	mockCollectionRepo.On("ListVisible", mock.Anything, mock.Anything).Return([]*domain.Collection{
+		{ID: uuid.New(), Title: "Staff Picks", AccessLevel: domain.AccessLevelFree, Items: []*domain.CollectionItem{
+			{Content: hidden}, {Content: picked},
+		}},
+		{ID: uuid.New(), Title: "Premium Only", AccessLevel: domain.AccessLevelPremium, Items: []*domain.CollectionItem{{Content: picked}}},
+		{ID: uuid.New(), Title: "Empty", AccessLevel: domain.AccessLevelFree},
+	}, nil)
+	mockCache.On("Get", mock.Anything, mock.Anything).Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockTrendingRepo.On("ListTrending", mock.Anything, mock.Anything, "US", 10).Return([]*domain.ContentActivity{
+		{ContentID: hot.ID, Content: hot, UniqueViewers: 9},
+	}, nil)
+	mockContentRepo.On("ListGenres", mock.Anything, mock.AnythingOfType("repositories.ContentScope"), mock.Anything).Return([]string{"horror"}, nil)
+	mockContentRepo.On("List", mock.Anything, mock.MatchedBy(func(filter repositories.ContentFilter) bool {
+		return filter.PublishedOnly() && filter.Genre == "horror"
+	}), mock.AnythingOfType("repositories.ContentScope"), mock.Anything).
+		Return([]*domain.Content{scary}, int64(1), nil)
+
+	uc := usecases.NewHomeUseCase(mockCollectionRepo, mockContentRepo, mockWatchRepo, mockSubRepo, usecases.NewTrendingUseCase(mockTrendingRepo, mockCache))
+	rails, err := uc.GetHome(context.Background(), &userID, "US")
+
+	assert.NoError(t, err)
+	assert.Len(t, rails, 4)
+	assert.Equal(t, usecases.HomeRailContinueWatching, rails[0].Type)
+	assert.Equal(t, 50.0, rails[0].Items[0].Progress.ProgressPercentage)
+	assert.Equal(t, "Staff Picks", rails[1].Title)
+	assert.Len(t, rails[1].Items, 1)
+	assert.Equal(t, picked.ID, rails[1].Items[0].Content.ID)
+	assert.Equal(t, usecases.HomeRailTrending, rails[2].Type)
+	assert.Equal(t, "Horror", rails[3].Title)
+	assert.Equal(t, "genre:horror", rails[3].ID)
+}
+
+func TestGetHome_AnonymousSkipsContinueWatching(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockTrendingRepo := new(MockTrendingRepository)
+	mockCache := new(MockCache)
+
+	mockCollectionRepo.On("ListVisible", mock.Anything, mock.Anything).Return([]*domain.Collection{}, nil)
+	mockCache.On("Get", mock.Anything, mock.Anything).Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockTrendingRepo.On("ListTrending", mock.Anything, mock.Anything, "", 10).Return([]*domain.ContentActivity{}, nil)
+	mockContentRepo.On("ListGenres", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+
+	watchRepo := new(MockWatchHistoryRepository)
+	uc := usecases.NewHomeUseCase(mockCollectionRepo, mockContentRepo, watchRepo, new(MockSubscriptionRepository), usecases.NewTrendingUseCase(mockTrendingRepo, mockCache))
+	rails, err := uc.GetHome(context.Background(), nil, "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, rails)
+	watchRepo.AssertNotCalled(t, "GetContinueWatching", mock.Anything, mock.Anything, mock.Anything)
+}
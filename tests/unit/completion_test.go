@@ -0,0 +1,144 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompletionPolicy_IsComplete(t *testing.T) {
+	withCredits := &domain.Content{Markers: domain.ContentMarkers{CreditsStartSeconds: seconds(2400)}}
+
+	percentage := domain.CompletionPolicy{Percentage: 95}
+	assert.True(t, percentage.IsComplete(2850, 3000, nil))
+	assert.False(t, percentage.IsComplete(2849, 3000, nil))
+	assert.False(t, percentage.IsComplete(2400, 3000, withCredits))
+
+	remaining := domain.CompletionPolicy{SecondsRemaining: 120}
+	assert.True(t, remaining.IsComplete(2880, 3000, nil))
+	assert.False(t, remaining.IsComplete(2879, 3000, nil))
+
+	credits := domain.CompletionPolicy{Percentage: 90, UseCreditsMarker: true}
+	assert.True(t, credits.IsComplete(2400, 3000, withCredits))
+	assert.False(t, credits.IsComplete(2399, 3000, withCredits))
+	assert.False(t, credits.IsComplete(0, 0, nil))
+}
+
+func TestParseCompletionPolicies_MergesOverrides(t *testing.T) {
+	policies, err := domain.ParseCompletionPolicies(domain.DefaultCompletionPolicy, `{"episode": {"seconds_remaining": 60}, "short": {"percentage": 100, "use_credits_marker": false}}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CompletionPolicy{Percentage: 90, SecondsRemaining: 60, UseCreditsMarker: true}, policies.For(domain.ContentTypeEpisode))
+	assert.Equal(t, domain.CompletionPolicy{Percentage: 100}, policies.For(domain.ContentTypeShort))
+	assert.Equal(t, domain.DefaultCompletionPolicy, policies.For(domain.ContentTypeMovie))
+}
+
+func TestParseCompletionPolicies_Invalid(t *testing.T) {
+	for name, overrides := range map[string]string{
+		"malformed":         `{"episode":`,
+		"unknown type":      `{"podcast": {"percentage": 80}}`,
+		"never completes":   `{"episode": {"percentage": 0}}`,
+		"percentage bounds": `{"movie": {"percentage": 120}}`,
+	} {
+		_, err := domain.ParseCompletionPolicies(domain.DefaultCompletionPolicy, overrides)
+		assert.Equal(t, domain.ErrInvalidCompletionPolicy, err, name)
+	}
+	_, err := domain.ParseCompletionPolicies(domain.CompletionPolicy{UseCreditsMarker: true}, "")
+	assert.Equal(t, domain.ErrInvalidCompletionPolicy, err)
+}
+
+func TestUpdateProgress_AppliesContentTypePolicy(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockWatchlistRepo := new(MockWatchlistRepository)
+
+	userID := uuid.New()
+	history := &domain.WatchHistory{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ContentID:    uuid.New(),
+		TotalSeconds: 1500,
+		Status:       domain.WatchStatusPaused,
+		Content:      &domain.Content{ContentType: domain.ContentTypeEpisode, AccessLevel: domain.AccessLevelFree, DurationSeconds: 1500},
+	}
+	mockContentRepo := new(MockContentRepository)
+	mockContentRepo.On("GetByID", mock.Anything, history.ContentID).Return(history.Content, nil)
+	mockWatchRepo.On("GetByID", mock.Anything, history.ID).Return(history, nil)
+	mockWatchRepo.On("Update", mock.Anything, history).Return(nil)
+	mockWatchlistRepo.On("Remove", mock.Anything, userID, history.ContentID).Return(nil)
+
+	policies, err := domain.ParseCompletionPolicies(domain.DefaultCompletionPolicy, `{"episode": {"seconds_remaining": 180}}`)
+	assert.NoError(t, err)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), mockWatchlistRepo, policies, nil)
+	result, err := watchUseCase.UpdateProgress(context.Background(), userID, history.ID, "", 1330)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusCompleted, result.Status)
+	assert.NotNil(t, result.CompletedAt)
+	mockWatchlistRepo.AssertExpectations(t)
+}
+
+func TestCreateOrUpdateWatchHistory_RestartStartsRewatch(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}
+	existing := &domain.WatchHistory{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ContentID:      content.ID,
+		WatchedSeconds: 7100,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusCompleted,
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(existing, nil)
+	mockWatchRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	watched := 15
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusStarted, result.Status)
+	assert.Equal(t, 1, result.RewatchCount)
+
+	watched = 3600
+	result, err = watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusPaused, result.Status)
+	assert.Equal(t, 1, result.RewatchCount)
+}
+
+func TestCreateOrUpdateWatchHistory_ScrubbingCompletedTitleIsNotRewatch(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}
+	existing := &domain.WatchHistory{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ContentID:      content.ID,
+		WatchedSeconds: 7100,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusCompleted,
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(existing, nil)
+	mockWatchRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	watched := 6600
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusCompleted, result.Status)
+	assert.Equal(t, 0, result.RewatchCount)
+}
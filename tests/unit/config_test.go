@@ -0,0 +1,30 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_RejectsNonPositiveJobIntervals(t *testing.T) {
+	for _, value := range []string{"0", "-30"} {
+		t.Setenv("PROGRESS_FLUSH_INTERVAL", value)
+		_, err := config.Load()
+		assert.EqualError(t, err, "PROGRESS_FLUSH_INTERVAL must be greater than zero")
+	}
+}
+
+func TestLoad_UnparsableIntervalFallsBackToDefault(t *testing.T) {
+	t.Setenv("TRASH_PURGE_INTERVAL", "hourly")
+	cfg, err := config.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 3600, cfg.TrashPurgeInterval)
+}
+
+func TestLoad_RejectsNonPositiveTrashRetention(t *testing.T) {
+	t.Setenv("TRASH_RETENTION_DAYS", "0")
+	_, err := config.Load()
+	assert.EqualError(t, err, "TRASH_RETENTION_DAYS must be greater than zero")
+}
@@ -2,13 +2,17 @@ package unit
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,8 +34,28 @@ func (m *MockContentRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return args.Get(0).(*domain.Content), args.Error(1)
 }
 
-func (m *MockContentRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*domain.Content, int64, error) {
-	args := m.Called(ctx, filters, limit, offset)
+func (m *MockContentRepository) GetByExternalID(ctx context.Context, externalID string) (*domain.Content, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Content), args.Error(1)
+}
+
+func (m *MockContentRepository) List(ctx context.Context, filter repositories.ContentFilter, scope repositories.ContentScope, page repositories.Page) ([]*domain.Content, int64, error) {
+	args := m.Called(ctx, filter, scope, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	var total int64
+	if args.Get(1) != nil {
+		total = args.Get(1).(int64)
+	}
+	return args.Get(0).([]*domain.Content), total, args.Error(2)
+}
+
+func (m *MockContentRepository) ListByPersonName(ctx context.Context, name string, filter repositories.ContentFilter, scope repositories.ContentScope, page repositories.Page) ([]*domain.Content, int64, error) {
+	args := m.Called(ctx, name, filter, scope, page)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -42,6 +66,30 @@ func (m *MockContentRepository) List(ctx context.Context, filters map[string]int
 	return args.Get(0).([]*domain.Content), total, args.Error(2)
 }
 
+func (m *MockContentRepository) ListGenres(ctx context.Context, scope repositories.ContentScope, limit int) ([]string, error) {
+	args := m.Called(ctx, scope, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockContentRepository) ListAvailableFromBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Content), args.Error(1)
+}
+
+func (m *MockContentRepository) ListAvailableUntilBetween(ctx context.Context, from, to time.Time) ([]*domain.Content, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Content), args.Error(1)
+}
+
 func (m *MockContentRepository) Update(ctx context.Context, content *domain.Content) error {
 	args := m.Called(ctx, content)
 	return args.Error(0)
@@ -52,14 +100,36 @@ func (m *MockContentRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockContentRepository) ListDeleted(ctx context.Context) ([]*domain.Content, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Content), args.Error(1)
+}
+
+func (m *MockContentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestCreateContent_Success(t *testing.T) {
 	mockContentRepo := new(MockContentRepository)
 	mockSubRepo := new(MockSubscriptionRepository)
 	mockUserRepo := new(MockUserRepository)
 
-	mockContentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(nil)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	authorID := uuid.New()
+	mockRevisionRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		return revision.AuthorID == authorID && revision.Status == domain.RevisionStatusPublished && revision.Changes["title"].To == "Test Movie"
+	}), false).Return(nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, mockRevisionRepo)
 
 	input := usecases.CreateContentInput{
 		Title:           "Test Movie",
@@ -69,13 +139,13 @@ func TestCreateContent_Success(t *testing.T) {
 		Published:       true,
 	}
 
-	content, err := contentUseCase.CreateContent(context.Background(), input)
+	content, err := contentUseCase.CreateContent(context.Background(), authorID, input)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, content)
 	assert.Equal(t, "Test Movie", content.Title)
 	assert.Equal(t, domain.AccessLevelPremium, content.AccessLevel)
-	mockContentRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
 }
 
 func TestGetContent_Published_FreeAccess_NoAuth(t *testing.T) {
@@ -93,8 +163,8 @@ func TestGetContent_Published_FreeAccess_NoAuth(t *testing.T) {
 
 	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
-	result, err := contentUseCase.GetContent(context.Background(), contentID, nil)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -117,8 +187,8 @@ func TestGetContent_Published_PremiumAccess_NoAuth(t *testing.T) {
 
 	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
-	result, err := contentUseCase.GetContent(context.Background(), contentID, nil)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -141,8 +211,8 @@ func TestGetContent_NotPublished(t *testing.T) {
 
 	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
-	result, err := contentUseCase.GetContent(context.Background(), contentID, nil)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -150,6 +220,235 @@ func TestGetContent_NotPublished(t *testing.T) {
 	mockContentRepo.AssertExpectations(t)
 }
 
+func TestGetContent_NotYetAvailable(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentID := uuid.New()
+	premiere := time.Now().Add(6 * time.Hour)
+	content := &domain.Content{
+		ID:            contentID,
+		Title:         "Midnight Premiere",
+		AccessLevel:   domain.AccessLevelFree,
+		Published:     true,
+		AvailableFrom: &premiere,
+	}
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailable, err)
+}
+
+func TestGetContent_Expired(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentID := uuid.New()
+	licenceEnd := time.Now().Add(-time.Minute)
+	content := &domain.Content{
+		ID:             contentID,
+		AccessLevel:    domain.AccessLevelFree,
+		Published:      true,
+		AvailableUntil: &licenceEnd,
+	}
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailable, err)
+}
+
+func TestGetContent_BlockedInRegion(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentID := uuid.New()
+	content := &domain.Content{
+		ID:               contentID,
+		AccessLevel:      domain.AccessLevelFree,
+		Published:        true,
+		BlockedCountries: domain.CountryCodes{"DE"},
+	}
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "DE")
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailableInRegion, err)
+
+	result, err = contentUseCase.GetContent(context.Background(), contentID, nil, "FR")
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestGetContent_AllowListUnknownCountry(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentID := uuid.New()
+	content := &domain.Content{
+		ID:               contentID,
+		AccessLevel:      domain.AccessLevelFree,
+		Published:        true,
+		AllowedCountries: domain.CountryCodes{"US", "CA"},
+	}
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, err := contentUseCase.GetContent(context.Background(), contentID, nil, "")
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailableInRegion, err)
+}
+
+func TestUpdateTerritories_NormalizesCodes(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+
+	authorID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	mockContentRepo.On("GetByID", mock.Anything, ids[0]).Return(&domain.Content{ID: ids[0], Title: "A"}, nil)
+	mockContentRepo.On("GetByID", mock.Anything, ids[1]).Return(&domain.Content{ID: ids[1], Title: "B"}, nil)
+	mockContentRepo.On("GetByID", mock.Anything, ids[2]).Return(nil, domain.ErrContentNotFound)
+	mockRevisionRepo.On("Record", mock.Anything, mock.MatchedBy(func(content *domain.Content) bool {
+		return content.AllowedCountries.Contains("US") && content.AllowedCountries.Contains("GB") && len(content.AllowedCountries) == 2
+	}), mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		_, changed := revision.Changes["allowed_countries"]
+		return revision.AuthorID == authorID && changed && len(revision.Changes) == 1
+	}), false).Return(nil).Twice()
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, mockRevisionRepo)
+	updated, err := contentUseCase.UpdateTerritories(context.Background(), authorID, usecases.UpdateTerritoriesInput{
+		ContentIDs:       ids,
+		AllowedCountries: []string{"us", " GB", "US"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+	mockContentRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestUpdateTerritories_InvalidCode(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	_, err := contentUseCase.UpdateTerritories(context.Background(), uuid.New(), usecases.UpdateTerritoriesInput{
+		ContentIDs:       []uuid.UUID{uuid.New()},
+		BlockedCountries: []string{"XX"},
+	})
+
+	assert.Equal(t, domain.ErrInvalidCountryCode, err)
+	mockContentRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestCreateContent_InvalidAvailabilityWindow(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	from := time.Now().Add(48 * time.Hour)
+	until := time.Now().Add(24 * time.Hour)
+
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, mockRevisionRepo)
+	content, err := contentUseCase.CreateContent(context.Background(), uuid.New(), usecases.CreateContentInput{
+		Title:          "Backwards Window",
+		AccessLevel:    domain.AccessLevelFree,
+		AvailableFrom:  &from,
+		AvailableUntil: &until,
+	})
+
+	assert.Nil(t, content)
+	assert.Equal(t, domain.ErrInvalidAvailability, err)
+	mockRevisionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAvailabilityScheduler_Tick_PublishesEvents(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	eventBus := infrastructure.NewEventBus()
+
+	premiere := time.Now().Add(-10 * time.Second)
+	licenceEnd := time.Now().Add(-5 * time.Second)
+	premiering := &domain.Content{ID: uuid.New(), Published: true, AvailableFrom: &premiere}
+	leaving := &domain.Content{ID: uuid.New(), Published: true, AvailableUntil: &licenceEnd}
+
+	mockContentRepo.On("ListAvailableFromBetween", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Content{premiering}, nil)
+	mockContentRepo.On("ListAvailableUntilBetween", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Content{leaving}, nil)
+
+	var received []domain.ContentEvent
+	record := func(ctx context.Context, event domain.ContentEvent) { received = append(received, event) }
+	eventBus.Subscribe(domain.ContentEventPublished, record)
+	eventBus.Subscribe(domain.ContentEventExpired, record)
+
+	mockCache := new(MockCache)
+	mockCache.On("Get", mock.Anything, "availability:last_run").Return("", redis.Nil)
+	mockCache.On("Set", mock.Anything, "availability:last_run", mock.Anything, time.Duration(0)).Return(nil)
+
+	scheduler := usecases.NewAvailabilityScheduler(mockContentRepo, eventBus, mockCache)
+	err := scheduler.Tick(context.Background(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Len(t, received, 2)
+	assert.Equal(t, domain.ContentEvent{Type: domain.ContentEventPublished, ContentID: premiering.ID, OccurredAt: premiere}, received[0])
+	assert.Equal(t, domain.ContentEvent{Type: domain.ContentEventExpired, ContentID: leaving.ID, OccurredAt: licenceEnd}, received[1])
+	mockContentRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestAvailabilityScheduler_Tick_ResumesFromStoredWatermark(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockCache := new(MockCache)
+
+	lastRun := time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)
+	now := lastRun.Add(3 * time.Hour)
+	mockCache.On("Get", mock.Anything, "availability:last_run").Return(lastRun.Format(time.RFC3339Nano), nil).Once()
+	mockCache.On("Set", mock.Anything, "availability:last_run", now.Format(time.RFC3339Nano), time.Duration(0)).Return(nil)
+	mockContentRepo.On("ListAvailableFromBetween", mock.Anything, lastRun, now).Return([]*domain.Content{}, nil)
+	mockContentRepo.On("ListAvailableUntilBetween", mock.Anything, lastRun, now).Return([]*domain.Content{}, nil)
+
+	scheduler := usecases.NewAvailabilityScheduler(mockContentRepo, infrastructure.NewEventBus(), mockCache)
+	assert.NoError(t, scheduler.Tick(context.Background(), now))
+	mockContentRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestListLeavingSoon_SkipsUnreleasedAndBlockedTitles(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+
+	premiere := time.Now().Add(24 * time.Hour)
+	licenceEnd := time.Now().Add(72 * time.Hour)
+	leaving := &domain.Content{ID: uuid.New(), Published: true, AvailableUntil: &licenceEnd}
+	unreleased := &domain.Content{ID: uuid.New(), Published: true, AvailableFrom: &premiere, AvailableUntil: &licenceEnd}
+	blocked := &domain.Content{ID: uuid.New(), Published: true, AvailableUntil: &licenceEnd, BlockedCountries: domain.CountryCodes{"US"}}
+
+	mockContentRepo.On("ListAvailableUntilBetween", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Content{leaving, unreleased, blocked}, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	result, err := contentUseCase.ListLeavingSoon(context.Background(), "US", 7*24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Content{leaving}, result)
+}
+
 func TestListContent_Success(t *testing.T) {
 	mockContentRepo := new(MockContentRepository)
 	mockSubRepo := new(MockSubscriptionRepository)
@@ -160,20 +459,99 @@ func TestListContent_Success(t *testing.T) {
 		{ID: uuid.New(), Title: "Movie 2", Published: true},
 	}
 
-	mockContentRepo.On("List", mock.Anything, mock.MatchedBy(func(filters map[string]interface{}) bool {
-		val, exists := filters["published"]
-		return exists && val == true
-	}), 20, 0).Return(contents, int64(2), nil)
+	mockContentRepo.On("List", mock.Anything, mock.MatchedBy(func(filter repositories.ContentFilter) bool {
+		return filter.PublishedOnly()
+	}), mock.AnythingOfType("repositories.ContentScope"), repositories.Page{
+		Sort: repositories.SortCreatedAt, Desc: true, Limit: 21,
+	}).Return(contents, int64(2), nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
-	result, total, err := contentUseCase.ListContent(context.Background(), true, 20, 0)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, total, next, err := contentUseCase.ListContent(context.Background(), publishedFilter(), "", repositories.Page{
+		Sort: repositories.SortCreatedAt, Desc: true, Limit: 20,
+	})
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, int64(2), total)
+	assert.Nil(t, next)
 	mockContentRepo.AssertExpectations(t)
 }
 
+func TestListContentByPerson_Success(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contents := []*domain.Content{
+		{ID: uuid.New(), Title: "Inception", Published: true},
+	}
+
+	mockContentRepo.On("ListByPersonName", mock.Anything, "Nolan", mock.MatchedBy(func(filter repositories.ContentFilter) bool {
+		return filter.PublishedOnly()
+	}), mock.AnythingOfType("repositories.ContentScope"), mock.AnythingOfType("repositories.Page")).Return(contents, int64(1), nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
+	result, total, _, err := contentUseCase.ListContentByPerson(context.Background(), "Nolan", publishedFilter(), "", repositories.Page{
+		Sort: repositories.SortTitle, Limit: 20,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1), total)
+	mockContentRepo.AssertExpectations(t)
+}
+
+func publishedFilter() repositories.ContentFilter {
+	published := true
+	return repositories.ContentFilter{Published: &published}
+}
+
+func TestListContent_PassesTypedFilter(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	maxDuration := 1800
+	filter := publishedFilter()
+	filter.AccessLevels = []domain.AccessLevel{domain.AccessLevelFree}
+	filter.MaxDuration = &maxDuration
+
+	mockContentRepo.On("List", mock.Anything, filter, mock.MatchedBy(func(scope repositories.ContentScope) bool {
+		return scope.AvailableAt != nil && scope.Country != nil && *scope.Country == "US"
+	}), mock.Anything).Return([]*domain.Content{}, int64(0), nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	_, _, _, err := contentUseCase.ListContent(context.Background(), filter, "US", repositories.Page{Sort: repositories.SortTitle, Limit: 20})
+
+	assert.NoError(t, err)
+	mockContentRepo.AssertExpectations(t)
+}
+
+func TestContentFilter_Validate(t *testing.T) {
+	short, long := 600, 1800
+	from := time.Now()
+	to := from.Add(time.Hour)
+
+	valid := repositories.ContentFilter{
+		AccessLevels:  []domain.AccessLevel{domain.AccessLevelFree, domain.AccessLevelBasic},
+		MinDuration:   &short,
+		MaxDuration:   &long,
+		CreatedAfter:  &from,
+		CreatedBefore: &to,
+		Query:         "space",
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, repositories.ContentFilter{}.Validate())
+
+	negative := -1
+	for name, filter := range map[string]repositories.ContentFilter{
+		"unknown access level": {AccessLevels: []domain.AccessLevel{"gold"}},
+		"negative duration":    {MinDuration: &negative},
+		"inverted duration":    {MinDuration: &long, MaxDuration: &short},
+		"inverted created":     {CreatedAfter: &to, CreatedBefore: &from},
+		"query too long":       {Query: strings.Repeat("a", repositories.MaxFilterQueryLength+1)},
+	} {
+		assert.Equal(t, domain.ErrInvalidFilter, filter.Validate(), name)
+	}
+}
+
 func TestUpdateContent_Success(t *testing.T) {
 	mockContentRepo := new(MockContentRepository)
 	mockSubRepo := new(MockSubscriptionRepository)
@@ -186,21 +564,23 @@ func TestUpdateContent_Success(t *testing.T) {
 	}
 
 	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
-	mockContentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(nil)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	mockRevisionRepo.On("Record", mock.Anything, content, mock.AnythingOfType("*domain.ContentRevision"), false).Return(nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, mockRevisionRepo)
 
 	input := usecases.CreateContentInput{
 		Title:       "Updated Title",
 		AccessLevel: domain.AccessLevelBasic,
 	}
 
-	result, err := contentUseCase.UpdateContent(context.Background(), contentID, input)
+	result, err := contentUseCase.UpdateContent(context.Background(), uuid.New(), contentID, input)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated Title", result.Title)
 	mockContentRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
 }
 
 func TestDeleteContent_Success(t *testing.T) {
@@ -211,7 +591,7 @@ func TestDeleteContent_Success(t *testing.T) {
 	contentID := uuid.New()
 	mockContentRepo.On("Delete", mock.Anything, contentID).Return(nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
 	err := contentUseCase.DeleteContent(context.Background(), contentID)
 
 	assert.NoError(t, err)
@@ -224,7 +604,7 @@ func TestCheckAccess_FreeContent(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 
 	userID := uuid.New()
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
 
 	hasAccess, err := contentUseCase.CheckAccess(context.Background(), userID, domain.AccessLevelFree)
 
@@ -240,7 +620,7 @@ func TestCheckAccess_PremiumContent_NoSubscription(t *testing.T) {
 	userID := uuid.New()
 	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
 	hasAccess, err := contentUseCase.CheckAccess(context.Background(), userID, domain.AccessLevelPremium)
 
 	assert.NoError(t, err)
@@ -264,7 +644,7 @@ func TestCheckAccess_PremiumContent_WithValidSubscription(t *testing.T) {
 
 	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(subscription, nil)
 
-	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo)
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, mockUserRepo, new(MockContentRevisionRepository))
 	hasAccess, err := contentUseCase.CheckAccess(context.Background(), userID, domain.AccessLevelPremium)
 
 	assert.NoError(t, err)
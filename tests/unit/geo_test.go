@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/cmd/server/middleware"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const geoIPFixture = `# start_ip,end_ip,country
+1.0.0.0,1.0.0.255,AU
+8.8.8.0,8.8.8.255,US
+81.2.69.0,81.2.69.255,GB
+2001:db8::,2001:db8::ffff,DE
+`
+
+func newGeoResolver(t *testing.T, header string, trustedProxies ...string) *infrastructure.GeoResolver {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte(geoIPFixture), 0o600))
+	resolver, err := infrastructure.NewGeoResolver(header, path, trustedProxies)
+	require.NoError(t, err)
+	return resolver
+}
+
+func TestGeoResolver_Lookup(t *testing.T) {
+	resolver := newGeoResolver(t, "")
+
+	assert.Equal(t, "US", resolver.Lookup("8.8.8.8"))
+	assert.Equal(t, "GB", resolver.Lookup("81.2.69.142"))
+	assert.Equal(t, "AU", resolver.Lookup("::ffff:1.0.0.1"))
+	assert.Equal(t, "DE", resolver.Lookup("2001:db8::1"))
+	assert.Equal(t, "", resolver.Lookup("8.8.9.1"))
+	assert.Equal(t, "", resolver.Lookup("not-an-ip"))
+}
+
+func TestGeoResolver_PrefersValidHeader(t *testing.T) {
+	resolver := newGeoResolver(t, "CF-IPCountry", "10.0.0.0/8")
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("CF-IPCountry", "in")
+	assert.Equal(t, "IN", resolver.Country(req, "10.1.2.3", "8.8.8.8"))
+
+	req.Header.Set("CF-IPCountry", "XX")
+	assert.Equal(t, "US", resolver.Country(req, "10.1.2.3", "8.8.8.8"))
+}
+
+func TestGeoResolver_IgnoresHeaderFromUntrustedPeer(t *testing.T) {
+	resolver := newGeoResolver(t, "CF-IPCountry", "10.0.0.1")
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("CF-IPCountry", "IN")
+	assert.Equal(t, "US", resolver.Country(req, "8.8.8.8", "8.8.8.8"))
+	assert.Equal(t, "IN", resolver.Country(req, "::ffff:10.0.0.1", "8.8.8.8"))
+}
+
+func TestGeoResolver_InvalidDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1.0.0.0,bogus,AU\n"), 0o600))
+
+	_, err := infrastructure.NewGeoResolver("", path, nil)
+	assert.Error(t, err)
+}
+
+func TestGeoMiddleware_IgnoresForwardedForFromUntrustedClients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resolver := newGeoResolver(t, "")
+	resolve := func(trusted []string) string {
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(trusted))
+		router.Use(middleware.GeoMiddleware(resolver))
+		router.GET("/", func(c *gin.Context) { c.String(http.StatusOK, c.GetString("country")) })
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "81.2.69.142:4000"
+		req.Header.Set("X-Forwarded-For", "8.8.8.8")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "GB", resolve(nil))
+	assert.Equal(t, "US", resolve([]string{"81.2.69.0/24"}))
+}
+
+func TestNewGeoResolver_InvalidTrustedProxy(t *testing.T) {
+	_, err := infrastructure.NewGeoResolver("CF-IPCountry", "", []string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package unit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solidImage(width, height int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: c}, image.Point{}, draw.Src)
+	return img
+}
+
+func TestResize_KeepsAspectRatioAndColor(t *testing.T) {
+	src := solidImage(1920, 1080, color.RGBA{R: 200, G: 40, B: 10, A: 255})
+
+	dst := imaging.Resize(src, 320)
+
+	assert.Equal(t, 320, dst.Bounds().Dx())
+	assert.Equal(t, 180, dst.Bounds().Dy())
+	assert.Equal(t, color.RGBA{R: 200, G: 40, B: 10, A: 255}, dst.RGBAAt(160, 90))
+}
+
+func TestResize_AveragesCoveredPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	src.SetRGBA(0, 0, color.RGBA{A: 255})
+	src.SetRGBA(1, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	src.SetRGBA(2, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	src.SetRGBA(3, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+
+	dst := imaging.Resize(src, 2)
+
+	assert.Equal(t, uint8(128), dst.RGBAAt(0, 0).R)
+	assert.Equal(t, uint8(255), dst.RGBAAt(1, 0).R)
+}
+
+func TestResize_NeverUpscales(t *testing.T) {
+	dst := imaging.Resize(solidImage(200, 100, color.White), 640)
+
+	assert.Equal(t, 200, dst.Bounds().Dx())
+	assert.Equal(t, 100, dst.Bounds().Dy())
+}
+
+func TestDominantColor(t *testing.T) {
+	img := solidImage(100, 100, color.RGBA{R: 10, G: 120, B: 200, A: 255})
+	draw.Draw(img, image.Rect(0, 0, 30, 100), &image.Uniform{C: color.RGBA{R: 250, A: 255}}, image.Point{}, draw.Src)
+
+	assert.Equal(t, "#0a78c8", imaging.DominantColor(img))
+}
+
+func TestBlurHash_SolidColor(t *testing.T) {
+	hash, err := imaging.BlurHash(solidImage(64, 48, color.RGBA{R: 255, A: 255}), 4, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, "LDTI:j]9fQ]9|co1fQo1fQfQfQfQ", hash)
+}
+
+func TestBlurHash_InvalidComponents(t *testing.T) {
+	_, err := imaging.BlurHash(solidImage(8, 8, color.White), 0, 10)
+	assert.Error(t, err)
+}
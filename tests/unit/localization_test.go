@@ -0,0 +1,174 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) ListContentTranslations(ctx context.Context, contentIDs []uuid.UUID, locales []string) ([]*domain.ContentTranslation, error) {
+	args := m.Called(ctx, contentIDs, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentTranslation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) UpsertContentTranslation(ctx context.Context, translation *domain.ContentTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+
+func (m *MockTranslationRepository) DeleteContentTranslation(ctx context.Context, contentID uuid.UUID, locale string) error {
+	args := m.Called(ctx, contentID, locale)
+	return args.Error(0)
+}
+
+func (m *MockTranslationRepository) ListPlanTranslations(ctx context.Context, planIDs []uuid.UUID, locales []string) ([]*domain.PlanTranslation, error) {
+	args := m.Called(ctx, planIDs, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PlanTranslation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) UpsertPlanTranslation(ctx context.Context, translation *domain.PlanTranslation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+
+func (m *MockTranslationRepository) DeletePlanTranslation(ctx context.Context, planID uuid.UUID, locale string) error {
+	args := m.Called(ctx, planID, locale)
+	return args.Error(0)
+}
+
+func newLocalizationUseCase(translationRepo *MockTranslationRepository, contentRepo *MockContentRepository, userRepo *MockUserRepository) *usecases.LocalizationUseCase {
+	return usecases.NewLocalizationUseCase(translationRepo, contentRepo, new(MockPlanRepository), userRepo, "en")
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tags := domain.ParseAcceptLanguage("fr-ca;q=0.8, es, *;q=0.5, de;q=0, not_a tag, pt_br;q=0.9")
+
+	assert.Equal(t, []string{"es", "pt-BR", "fr-CA"}, tags)
+	assert.Empty(t, domain.ParseAcceptLanguage(""))
+}
+
+func TestLocaleChain(t *testing.T) {
+	assert.Equal(t, []string{"pt-BR", "pt", "es", "en"}, domain.LocaleChain([]string{"pt-BR", "es"}, "en"))
+	assert.Equal(t, []string{"fr", "en"}, domain.LocaleChain([]string{"fr", "en", "es"}, "en"))
+	assert.Equal(t, []string{"en-GB", "en"}, domain.LocaleChain([]string{"en-GB", "de"}, "en"))
+	assert.Equal(t, []string{"en"}, domain.LocaleChain(nil, "en"))
+}
+
+func TestLocalizeContents_FallsBackPerField(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+
+	translated := &domain.Content{ID: uuid.New(), Title: "The Heist", Description: "A crew plans one last job."}
+	untranslated := &domain.Content{ID: uuid.New(), Title: "Ronin", Description: "Mercenaries chase a case."}
+	mockTranslationRepo.On("ListContentTranslations", mock.Anything, []uuid.UUID{translated.ID, untranslated.ID}, []string{"pt-BR", "pt"}).
+		Return([]*domain.ContentTranslation{
+			{ContentID: translated.ID, Locale: "pt-BR", Title: "O Grande Golpe"},
+			{ContentID: translated.ID, Locale: "pt", Title: "O Golpe", Description: "Uma equipa planeia um último golpe."},
+		}, nil)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, new(MockContentRepository), new(MockUserRepository))
+	err := uc.LocalizeContents(context.Background(), nil, []string{"pt-BR"}, translated, untranslated)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "O Grande Golpe", translated.Title)
+	assert.Equal(t, "Uma equipa planeia um último golpe.", translated.Description)
+	assert.Equal(t, "pt-BR", translated.Locale)
+	assert.Equal(t, "Ronin", untranslated.Title)
+	assert.Equal(t, "en", untranslated.Locale)
+}
+
+func TestLocalizeContents_UserPreferenceWins(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), Title: "The Heist"}
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, PreferredLocale: "es"}, nil)
+	mockTranslationRepo.On("ListContentTranslations", mock.Anything, []uuid.UUID{content.ID}, []string{"es", "fr"}).
+		Return([]*domain.ContentTranslation{
+			{ContentID: content.ID, Locale: "fr", Title: "Le Casse"},
+			{ContentID: content.ID, Locale: "es", Title: "El Golpe"},
+		}, nil)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, new(MockContentRepository), mockUserRepo)
+	err := uc.LocalizeContents(context.Background(), &userID, []string{"fr"}, content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "El Golpe", content.Title)
+	assert.Equal(t, "es", content.Locale)
+}
+
+func TestLocalizeContents_StopsAtDefaultLocale(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+
+	content := &domain.Content{ID: uuid.New(), Title: "The Heist"}
+	mockTranslationRepo.On("ListContentTranslations", mock.Anything, []uuid.UUID{content.ID}, []string{"en-US"}).
+		Return([]*domain.ContentTranslation{}, nil)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, new(MockContentRepository), new(MockUserRepository))
+	err := uc.LocalizeContents(context.Background(), nil, []string{"en-US", "fr"}, content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "The Heist", content.Title)
+	assert.Equal(t, "en", content.Locale)
+	mockTranslationRepo.AssertExpectations(t)
+}
+
+func TestPutContentTranslation_NormalizesLocale(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockTranslationRepo.On("UpsertContentTranslation", mock.Anything, mock.MatchedBy(func(translation *domain.ContentTranslation) bool {
+		return translation.ContentID == contentID && translation.Locale == "pt-BR" && translation.Title == "O Golpe"
+	})).Return(nil)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, mockContentRepo, new(MockUserRepository))
+	translation, err := uc.PutContentTranslation(context.Background(), contentID, "pt_br", usecases.ContentTranslationInput{Title: "O Golpe"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pt-BR", translation.Locale)
+	mockTranslationRepo.AssertExpectations(t)
+}
+
+func TestPutContentTranslation_RejectsDefaultLocale(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, new(MockContentRepository), new(MockUserRepository))
+	translation, err := uc.PutContentTranslation(context.Background(), uuid.New(), "EN", usecases.ContentTranslationInput{Title: "The Heist"})
+
+	assert.Nil(t, translation)
+	assert.Equal(t, domain.ErrDefaultLocaleTranslation, err)
+	mockTranslationRepo.AssertNotCalled(t, "UpsertContentTranslation", mock.Anything, mock.Anything)
+}
+
+func TestLocalizePlans(t *testing.T) {
+	mockTranslationRepo := new(MockTranslationRepository)
+
+	plan := &domain.Plan{ID: uuid.New(), Name: "Premium", Description: "All titles in 4K"}
+	mockTranslationRepo.On("ListPlanTranslations", mock.Anything, []uuid.UUID{plan.ID}, []string{"de"}).
+		Return([]*domain.PlanTranslation{{PlanID: plan.ID, Locale: "de", Description: "Alle Titel in 4K"}}, nil)
+
+	uc := newLocalizationUseCase(mockTranslationRepo, new(MockContentRepository), new(MockUserRepository))
+	err := uc.LocalizePlans(context.Background(), nil, []string{"de"}, plan)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Premium", plan.Name)
+	assert.Equal(t, "Alle Titel in 4K", plan.Description)
+	assert.Equal(t, "en", plan.Locale)
+}
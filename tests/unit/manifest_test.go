@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"context"
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/manifest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func goldenVariants() []manifest.Variant {
+	return []manifest.Variant{
+		{ID: "480p-1200", BandwidthKbps: 1200, Width: 854, Height: 480, Codecs: "avc1.64001e,mp4a.40.2", URI: "https://cdn.example.com/v/480/index.m3u8?exp=1700000000&sig=abc&uid=u1"},
+		{ID: "720p-3000", BandwidthKbps: 3000, Width: 1280, Height: 720, Codecs: "avc1.64001f,mp4a.40.2", URI: "https://cdn.example.com/v/720/index.m3u8?exp=1700000000&sig=def&uid=u1"},
+		{ID: "1080p-6000", BandwidthKbps: 6000, Width: 1920, Height: 1080, Codecs: "avc1.640028,mp4a.40.2", URI: "https://cdn.example.com/v/1080/index.m3u8?exp=1700000000&sig=ghi&uid=u1"},
+	}
+}
+
+func TestHLSManifest_Golden(t *testing.T) {
+	assertGolden(t, "master.m3u8", manifest.HLS(goldenVariants()))
+}
+
+func TestDASHManifest_Golden(t *testing.T) {
+	body, err := manifest.DASH(goldenVariants(), 5425*time.Second)
+	require.NoError(t, err)
+	assertGolden(t, "manifest.mpd", body)
+}
+
+func TestCreateManifest_FiltersAndSignsVariants(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelBasic, Published: true, DurationSeconds: 600}
+	renditions := []*domain.VideoRendition{
+		{ContentID: contentID, Resolution: domain.Resolution720p, BitrateKbps: 3000, Codec: "avc1.64001f", URL: "https://cdn.example.com/v/720/index.m3u8"},
+		{ContentID: contentID, Resolution: domain.Resolution1080p, BitrateKbps: 6000, Codec: "avc1.640028", URL: "https://cdn.example.com/v/1080/index.m3u8"},
+	}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(activeSubscription(userID, domain.AccessLevelBasic, domain.Resolution720p), nil)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return(renditions, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	m, err := playbackUseCase.CreateManifest(context.Background(), contentID, userID, "", "", usecases.ManifestFormatHLS, usecases.PlaybackInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/vnd.apple.mpegurl", m.ContentType)
+	body := string(m.Body)
+	assert.Contains(t, body, "RESOLUTION=1280x720")
+	assert.NotContains(t, body, "1080")
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	variantURI, err := url.Parse(lines[len(lines)-1])
+	require.NoError(t, err)
+	assert.NotEmpty(t, variantURI.Query().Get("sig"))
+	assert.Equal(t, userID.String(), variantURI.Query().Get("uid"))
+}
+
+func TestCreateManifest_DASHContentType(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, Published: true, DurationSeconds: 90}
+	renditions := []*domain.VideoRendition{
+		{ContentID: contentID, Resolution: domain.Resolution480p, BitrateKbps: 1200, Codec: "avc1.64001e", URL: "https://cdn.example.com/v/480.mp4"},
+	}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return(renditions, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	m, err := playbackUseCase.CreateManifest(context.Background(), contentID, userID, "", "", usecases.ManifestFormatDASH, usecases.PlaybackInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/dash+xml", m.ContentType)
+	assert.Contains(t, string(m.Body), `mediaPresentationDuration="PT0H1M30S"`)
+	assert.Contains(t, string(m.Body), `width="854"`)
+}
+
+func TestCreateManifest_NoRenditions(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, Published: true, VideoURL: testVideoURL}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return([]*domain.VideoRendition{}, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	m, err := playbackUseCase.CreateManifest(context.Background(), contentID, userID, "", "", usecases.ManifestFormatHLS, usecases.PlaybackInput{})
+
+	assert.Nil(t, m)
+	assert.Equal(t, domain.ErrPlaybackUnavailable, err)
+}
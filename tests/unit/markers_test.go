@@ -0,0 +1,140 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func seconds(value int) *int {
+	return &value
+}
+
+func TestContentMarkers_Validate(t *testing.T) {
+	valid := domain.ContentMarkers{
+		IntroStartSeconds:   seconds(30),
+		IntroEndSeconds:     seconds(95),
+		CreditsStartSeconds: seconds(6900),
+		Chapters: domain.Chapters{
+			{Title: "Cold open", StartSeconds: 0},
+			{Title: "The job", StartSeconds: 1200},
+		},
+	}
+	assert.NoError(t, valid.Validate(7200))
+	assert.NoError(t, domain.ContentMarkers{}.Validate(7200))
+
+	for name, markers := range map[string]domain.ContentMarkers{
+		"intro without end":       {IntroStartSeconds: seconds(30)},
+		"intro ends before start": {IntroStartSeconds: seconds(95), IntroEndSeconds: seconds(30)},
+		"intro past runtime":      {IntroStartSeconds: seconds(30), IntroEndSeconds: seconds(7300)},
+		"credits at runtime":      {CreditsStartSeconds: seconds(7200)},
+		"credits before intro":    {IntroStartSeconds: seconds(30), IntroEndSeconds: seconds(95), CreditsStartSeconds: seconds(60)},
+		"untitled chapter":        {Chapters: domain.Chapters{{StartSeconds: 0}}},
+		"chapters out of order":   {Chapters: domain.Chapters{{Title: "B", StartSeconds: 600}, {Title: "A", StartSeconds: 600}}},
+	} {
+		assert.Equal(t, domain.ErrInvalidMarkers, markers.Validate(7200), name)
+	}
+}
+
+func TestReachedCompletionPoint_UsesCreditsMarker(t *testing.T) {
+	content := &domain.Content{DurationSeconds: 7200, Markers: domain.ContentMarkers{CreditsStartSeconds: seconds(6000)}}
+	history := &domain.WatchHistory{WatchedSeconds: 6000, TotalSeconds: 7200, Status: domain.WatchStatusPaused}
+
+	assert.True(t, history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, content))
+
+	history.WatchedSeconds = 5999
+	assert.False(t, history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, content))
+	assert.False(t, history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, nil))
+
+	history.WatchedSeconds = 6480
+	assert.True(t, history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, nil))
+}
+
+func TestReachedCompletionPoint_IgnoresCreditsBeyondTotal(t *testing.T) {
+	content := &domain.Content{Markers: domain.ContentMarkers{CreditsStartSeconds: seconds(7100)}}
+	history := &domain.WatchHistory{WatchedSeconds: 5500, TotalSeconds: 6000}
+
+	assert.True(t, history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, content))
+}
+
+func TestUpdateMarkers_RecordsRevision(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+
+	authorID, contentID := uuid.New(), uuid.New()
+	markers := domain.ContentMarkers{IntroStartSeconds: seconds(10), IntroEndSeconds: seconds(70), CreditsStartSeconds: seconds(3300)}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, DurationSeconds: 3600}, nil)
+	mockRevisionRepo.On("Record", mock.Anything, mock.MatchedBy(func(content *domain.Content) bool {
+		return content.Markers.Chapters != nil && *content.Markers.CreditsStartSeconds == 3300
+	}), mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		_, changed := revision.Changes["markers"]
+		return revision.AuthorID == authorID && changed && len(revision.Changes) == 1 &&
+			revision.Snapshot.Markers != nil && *revision.Snapshot.Markers.IntroEndSeconds == 70
+	}), false).Return(nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	content, err := contentUseCase.UpdateMarkers(context.Background(), authorID, contentID, markers)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3300, *content.Markers.CreditsStartSeconds)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestUpdateMarkers_OutsideRuntime(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, DurationSeconds: 3600}, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	content, err := contentUseCase.UpdateMarkers(context.Background(), uuid.New(), contentID, domain.ContentMarkers{CreditsStartSeconds: seconds(4000)})
+
+	assert.Nil(t, content)
+	assert.Equal(t, domain.ErrInvalidMarkers, err)
+	mockRevisionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContentSnapshot_WithoutMarkersKeepsLiveMarkers(t *testing.T) {
+	content := &domain.Content{ID: uuid.New(), Title: "Old", Markers: domain.ContentMarkers{CreditsStartSeconds: seconds(3000)}}
+	snapshot := content.Snapshot()
+	snapshot.Title = "New"
+	snapshot.Markers = nil
+
+	changes := content.Snapshot().Diff(snapshot)
+	assert.Len(t, changes, 1)
+	assert.Contains(t, changes, "title")
+	content.ApplySnapshot(snapshot)
+	assert.Equal(t, 3000, *content.Markers.CreditsStartSeconds)
+}
+
+func TestCreateWatchHistory_CompletesAtCreditsMarker(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockWatchlistRepo := new(MockWatchlistRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{
+		ID:              uuid.New(),
+		AccessLevel:     domain.AccessLevelFree,
+		DurationSeconds: 7200,
+		Markers:         domain.ContentMarkers{CreditsStartSeconds: seconds(6000)},
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(nil, domain.ErrWatchHistoryNotFound)
+	mockWatchRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.WatchHistory")).Return(nil)
+	mockWatchlistRepo.On("Remove", mock.Anything, userID, content.ID).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), mockWatchlistRepo, defaultCompletion, nil)
+	watched := 6100
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusCompleted, result.Status)
+	mockWatchlistRepo.AssertExpectations(t)
+}
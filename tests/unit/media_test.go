@@ -0,0 +1,157 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockObjectStorage struct {
+	mock.Mock
+}
+
+func (m *MockObjectStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	args := m.Called(ctx, key, body, size, contentType)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func newMediaUseCase(storage *MockObjectStorage, contentRepo *MockContentRepository, userRepo *MockUserRepository, revisionRepo *MockContentRevisionRepository) *usecases.MediaUseCase {
+	contentUseCase := usecases.NewContentUseCase(contentRepo, new(MockSubscriptionRepository), userRepo, revisionRepo)
+	return usecases.NewMediaUseCase(storage, contentUseCase, contentRepo, userRepo)
+}
+
+func TestUploadArtwork_StoresContentAddressedImage(t *testing.T) {
+	mockStorage := new(MockObjectStorage)
+	mockContentRepo := new(MockContentRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mockRevisionRepo := new(MockContentRevisionRepository)
+
+	authorID, contentID := uuid.New(), uuid.New()
+	data := testPNG(t, 4, 4)
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockRevisionRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		_, changed := revision.Changes["thumbnail_url"]
+		return revision.AuthorID == authorID && changed && len(revision.Changes) == 1
+	}), false).Return(nil).Once()
+	mockContentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Content")).Return(nil).Once()
+	mockStorage.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything, int64(len(data)), "image/png").Return(nil)
+
+	mediaUseCase := newMediaUseCase(mockStorage, mockContentRepo, mockUserRepo, mockRevisionRepo)
+	content, err := mediaUseCase.UploadArtwork(context.Background(), authorID, contentID, data)
+
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^https://cdn\.example\.com/artwork/([0-9a-f]{2})/[0-9a-f]{64}\.png$`), content.ThumbnailURL)
+
+	again, err := mediaUseCase.UploadArtwork(context.Background(), authorID, contentID, data)
+	require.NoError(t, err)
+	assert.Equal(t, content.ThumbnailURL, again.ThumbnailURL)
+	mockRevisionRepo.AssertExpectations(t)
+	mockContentRepo.AssertExpectations(t)
+}
+
+func TestUploadArtwork_GeneratesVariantsAndPlaceholder(t *testing.T) {
+	mockStorage := new(MockObjectStorage)
+	mockContentRepo := new(MockContentRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	contentID := uuid.New()
+	data := testPNG(t, 1500, 1000)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockRevisionRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.Content"), mock.AnythingOfType("*domain.ContentRevision"), false).Return(nil)
+	mockStorage.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("int64"), "image/png").Return(nil)
+
+	mediaUseCase := newMediaUseCase(mockStorage, mockContentRepo, mockUserRepo, mockRevisionRepo)
+	content, err := mediaUseCase.UploadArtwork(context.Background(), uuid.New(), contentID, data)
+
+	require.NoError(t, err)
+	require.Len(t, content.ThumbnailVariants, 4)
+	widths := []int{}
+	for _, variant := range content.ThumbnailVariants {
+		widths = append(widths, variant.Width)
+	}
+	assert.Equal(t, []int{320, 640, 1280, 1500}, widths)
+	assert.Equal(t, 213, content.ThumbnailVariants[0].Height)
+	assert.Equal(t, content.ThumbnailURL, content.ThumbnailVariants[3].URL)
+	assert.Equal(t, "#000000", content.ThumbnailColor)
+	assert.Len(t, content.ThumbnailBlurHash, 28)
+	mockStorage.AssertNumberOfCalls(t, "Put", 4)
+}
+
+// pngHeader is a PNG that ends after declaring its dimensions
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12], ihdr[13] = 8, 6 // 8-bit RGBA
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, 13)
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestUploadArtwork_RejectsOversizedDimensions(t *testing.T) {
+	mockStorage := new(MockObjectStorage)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+
+	mediaUseCase := newMediaUseCase(mockStorage, mockContentRepo, new(MockUserRepository), new(MockContentRevisionRepository))
+	_, err := mediaUseCase.UploadArtwork(context.Background(), uuid.New(), contentID, pngHeader(50000, 50000))
+
+	assert.Equal(t, domain.ErrImageTooLarge, err)
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUploadAvatar_Validation(t *testing.T) {
+	mockStorage := new(MockObjectStorage)
+	mockContentRepo := new(MockContentRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	userID := uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+
+	mediaUseCase := newMediaUseCase(mockStorage, mockContentRepo, mockUserRepo, new(MockContentRevisionRepository))
+
+	_, err := mediaUseCase.UploadAvatar(context.Background(), userID, []byte("%PDF-1.7 definitely not an image"))
+	assert.Equal(t, domain.ErrUnsupportedMediaType, err)
+
+	_, err = mediaUseCase.UploadAvatar(context.Background(), userID, make([]byte, usecases.MaxAvatarBytes+1))
+	assert.Equal(t, domain.ErrFileTooLarge, err)
+
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/cursor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	codec := cursor.NewCodec([]byte("test-key"))
+	position := cursor.Position{Kind: "content", Sort: "title", Value: "Alien", ID: uuid.NewString()}
+
+	decoded, err := codec.Decode(codec.Encode(position))
+
+	assert.NoError(t, err)
+	assert.Equal(t, position, decoded)
+}
+
+func TestCursorCodec_RejectsTamperedAndForeignTokens(t *testing.T) {
+	codec := cursor.NewCodec([]byte("test-key"))
+	token := codec.Encode(cursor.Position{Kind: "content", Sort: "title", Value: "Alien", ID: uuid.NewString()})
+	payload, signature, _ := strings.Cut(token, ".")
+
+	forged := cursor.NewCodec([]byte("test-key")).Encode(cursor.Position{Kind: "content", Sort: "title", Value: "Zulu"})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, bad := range []string{
+		"",
+		payload,
+		forgedPayload + "." + signature,
+		cursor.NewCodec([]byte("other-key")).Encode(cursor.Position{Kind: "content"}),
+	} {
+		_, err := codec.Decode(bad)
+		assert.ErrorIs(t, err, cursor.ErrInvalid, bad)
+	}
+}
+
+func TestSortKey_ValueRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)
+
+	value, err := repositories.SortCreatedAt.ParseValue(repositories.SortCreatedAt.FormatValue(at))
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(value.(time.Time)))
+
+	value, err = repositories.SortDuration.ParseValue(repositories.SortDuration.FormatValue(5400))
+	assert.NoError(t, err)
+	assert.Equal(t, 5400, value)
+
+	_, err = repositories.SortDuration.ParseValue("not-a-number")
+	assert.Error(t, err)
+}
+
+func TestListContent_ReturnsKeysetOfLastRowWhenMoreRemain(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	contents := []*domain.Content{
+		{ID: uuid.New(), Title: "A", DurationSeconds: 60},
+		{ID: uuid.New(), Title: "B", DurationSeconds: 90},
+		{ID: uuid.New(), Title: "C", DurationSeconds: 120},
+	}
+	mockContentRepo.On("List", mock.Anything, mock.Anything, mock.Anything, repositories.Page{
+		Sort: repositories.SortDuration, Limit: 3,
+	}).Return(contents, int64(10), nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	result, _, next, err := uc.ListContent(context.Background(), publishedFilter(), "", repositories.Page{Sort: repositories.SortDuration, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, &repositories.Keyset{Value: "90", ID: contents[1].ID}, next)
+}
+
+func TestListContent_ClampsLimitAndRejectsUnknownSort(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockContentRepo.On("List", mock.Anything, mock.Anything, mock.Anything, repositories.Page{
+		Sort: repositories.SortTitle, Limit: usecases.MaxPageSize + 1,
+	}).Return([]*domain.Content{}, int64(0), nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+
+	_, _, _, err := uc.ListContent(context.Background(), publishedFilter(), "", repositories.Page{Sort: repositories.SortTitle, Limit: 5000})
+	assert.NoError(t, err)
+	mockContentRepo.AssertExpectations(t)
+
+	_, _, _, err = uc.ListContent(context.Background(), publishedFilter(), "", repositories.Page{Sort: repositories.SortLastWatchedAt, Limit: 20})
+	assert.Equal(t, domain.ErrInvalidSort, err)
+}
@@ -0,0 +1,236 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPersonRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonRepository) Create(ctx context.Context, person *domain.Person) error {
+	args := m.Called(ctx, person)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Person, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Person), args.Error(1)
+}
+
+func (m *MockPersonRepository) List(ctx context.Context, name string, limit, offset int) ([]*domain.Person, int64, error) {
+	args := m.Called(ctx, name, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Person), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPersonRepository) Update(ctx context.Context, person *domain.Person) error {
+	args := m.Called(ctx, person)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) CreateCredit(ctx context.Context, credit *domain.ContentCredit) error {
+	args := m.Called(ctx, credit)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) GetCredit(ctx context.Context, contentID, personID uuid.UUID, role domain.PersonRole) (*domain.ContentCredit, error) {
+	args := m.Called(ctx, contentID, personID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ContentCredit), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetCreditsByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentCredit, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentCredit), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetCreditsByPersonID(ctx context.Context, personID uuid.UUID) ([]*domain.ContentCredit, error) {
+	args := m.Called(ctx, personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentCredit), args.Error(1)
+}
+
+func (m *MockPersonRepository) DeleteCredit(ctx context.Context, contentID, creditID uuid.UUID) error {
+	args := m.Called(ctx, contentID, creditID)
+	return args.Error(0)
+}
+
+func filmographyFixture(personID uuid.UUID) []*domain.ContentCredit {
+	return []*domain.ContentCredit{
+		{PersonID: personID, Role: domain.PersonRoleDirector, Content: &domain.Content{Title: "Free Short", AccessLevel: domain.AccessLevelFree, Published: true}},
+		{PersonID: personID, Role: domain.PersonRoleDirector, Content: &domain.Content{Title: "Premium Feature", AccessLevel: domain.AccessLevelPremium, Published: true}},
+		{PersonID: personID, Role: domain.PersonRoleWriter, Content: &domain.Content{Title: "Unreleased", AccessLevel: domain.AccessLevelFree, Published: false}},
+	}
+}
+
+func TestCreatePerson_Success(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	mockPersonRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Person")).Return(nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	person, err := personUseCase.CreatePerson(context.Background(), usecases.CreatePersonInput{Name: "Christopher Nolan"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, person)
+	assert.Equal(t, "Christopher Nolan", person.Name)
+	mockPersonRepo.AssertExpectations(t)
+}
+
+func TestGetPerson_Anonymous_OnlyFreePublished(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	personID := uuid.New()
+	mockPersonRepo.On("GetByID", mock.Anything, personID).Return(&domain.Person{ID: personID, Name: "Christopher Nolan"}, nil)
+	mockPersonRepo.On("GetCreditsByPersonID", mock.Anything, personID).Return(filmographyFixture(personID), nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	person, filmography, err := personUseCase.GetPerson(context.Background(), personID, nil, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Christopher Nolan", person.Name)
+	assert.Len(t, filmography, 1)
+	assert.Equal(t, "Free Short", filmography[0].Content.Title)
+	mockSubRepo.AssertNotCalled(t, "GetActiveByUserID", mock.Anything, mock.Anything)
+}
+
+func TestGetPerson_PremiumSubscriber_AllPublished(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	personID := uuid.New()
+	userID := uuid.New()
+	subscription := &domain.Subscription{
+		UserID:   userID,
+		IsActive: true,
+		EndDate:  time.Now().Add(24 * time.Hour),
+		Plan:     &domain.Plan{AccessLevel: domain.AccessLevelPremium},
+	}
+	mockPersonRepo.On("GetByID", mock.Anything, personID).Return(&domain.Person{ID: personID}, nil)
+	mockPersonRepo.On("GetCreditsByPersonID", mock.Anything, personID).Return(filmographyFixture(personID), nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(subscription, nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	_, filmography, err := personUseCase.GetPerson(context.Background(), personID, &userID, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, filmography, 2)
+	mockSubRepo.AssertExpectations(t)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	personID := uuid.New()
+	mockPersonRepo.On("GetByID", mock.Anything, personID).Return(nil, domain.ErrPersonNotFound)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	person, _, err := personUseCase.GetPerson(context.Background(), personID, nil, "")
+
+	assert.Nil(t, person)
+	assert.Equal(t, domain.ErrPersonNotFound, err)
+}
+
+func TestAddCredit_Success(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	contentID := uuid.New()
+	personID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockPersonRepo.On("GetByID", mock.Anything, personID).Return(&domain.Person{ID: personID, Name: "Cillian Murphy"}, nil)
+	mockPersonRepo.On("GetCredit", mock.Anything, contentID, personID, domain.PersonRoleActor).Return(nil, domain.ErrCreditNotFound)
+	mockPersonRepo.On("CreateCredit", mock.Anything, mock.AnythingOfType("*domain.ContentCredit")).Return(nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	credit, err := personUseCase.AddCredit(context.Background(), contentID, usecases.AddCreditInput{
+		PersonID:      personID,
+		Role:          domain.PersonRoleActor,
+		CharacterName: "Robert Fischer",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, contentID, credit.ContentID)
+	assert.Equal(t, "Robert Fischer", credit.CharacterName)
+	assert.Equal(t, "Cillian Murphy", credit.Person.Name)
+	mockPersonRepo.AssertExpectations(t)
+}
+
+func TestAddCredit_AlreadyExists(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	contentID := uuid.New()
+	personID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockPersonRepo.On("GetByID", mock.Anything, personID).Return(&domain.Person{ID: personID}, nil)
+	mockPersonRepo.On("GetCredit", mock.Anything, contentID, personID, domain.PersonRoleDirector).Return(&domain.ContentCredit{}, nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	credit, err := personUseCase.AddCredit(context.Background(), contentID, usecases.AddCreditInput{
+		PersonID: personID,
+		Role:     domain.PersonRoleDirector,
+	})
+
+	assert.Nil(t, credit)
+	assert.Equal(t, domain.ErrCreditExists, err)
+	mockPersonRepo.AssertNotCalled(t, "CreateCredit", mock.Anything, mock.Anything)
+}
+
+func TestGetContentCredits_NotPublished(t *testing.T) {
+	mockPersonRepo := new(MockPersonRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, Published: false}, nil)
+
+	personUseCase := usecases.NewPersonUseCase(mockPersonRepo, mockContentRepo, mockSubRepo)
+	credits, err := personUseCase.GetContentCredits(context.Background(), contentID)
+
+	assert.Nil(t, credits)
+	assert.Equal(t, domain.ErrContentNotPublished, err)
+}
+
+func TestAccessLevel_Allows(t *testing.T) {
+	assert.True(t, domain.AccessLevelPremium.Allows(domain.AccessLevelBasic))
+	assert.True(t, domain.AccessLevelBasic.Allows(domain.AccessLevelFree))
+	assert.False(t, domain.AccessLevelBasic.Allows(domain.AccessLevelPremium))
+	assert.False(t, domain.AccessLevelFree.Allows(domain.AccessLevelBasic))
+}
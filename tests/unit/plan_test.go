@@ -3,6 +3,7 @@ package unit
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
@@ -55,6 +56,24 @@ func (m *MockPlanRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockPlanRepository) ListDeleted(ctx context.Context) ([]*domain.Plan, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Plan), args.Error(1)
+}
+
+func (m *MockPlanRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestCreatePlan_Success(t *testing.T) {
 	mockPlanRepo := new(MockPlanRepository)
 
@@ -69,7 +88,7 @@ func TestCreatePlan_Success(t *testing.T) {
 		ValidityDays:      30,
 		AccessLevel:       domain.AccessLevelPremium,
 		MaxDevicesAllowed: 4,
-		Resolution:        "4K",
+		Resolution:        "4k",
 		IsActive:          true,
 	}
 
@@ -79,9 +98,29 @@ func TestCreatePlan_Success(t *testing.T) {
 	assert.NotNil(t, plan)
 	assert.Equal(t, "Premium", plan.Name)
 	assert.Equal(t, int64(999), plan.Price)
+	assert.Equal(t, domain.Resolution4K, plan.Resolution)
 	mockPlanRepo.AssertExpectations(t)
 }
 
+func TestCreatePlan_InvalidResolution(t *testing.T) {
+	mockPlanRepo := new(MockPlanRepository)
+
+	planUseCase := usecases.NewPlanUseCase(mockPlanRepo)
+
+	price := int64(999)
+	plan, err := planUseCase.CreatePlan(context.Background(), usecases.CreatePlanInput{
+		Name:         "Ultra",
+		Price:        &price,
+		ValidityDays: 30,
+		AccessLevel:  domain.AccessLevelPremium,
+		Resolution:   "8K",
+	})
+
+	assert.Nil(t, plan)
+	assert.Equal(t, domain.ErrInvalidResolution, err)
+	mockPlanRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestGetPlan_Success(t *testing.T) {
 	mockPlanRepo := new(MockPlanRepository)
 
@@ -179,3 +218,32 @@ func TestDeletePlan_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockPlanRepo.AssertExpectations(t)
 }
+
+func TestRestorePlan_Success(t *testing.T) {
+	mockPlanRepo := new(MockPlanRepository)
+
+	planID := uuid.New()
+	mockPlanRepo.On("Restore", mock.Anything, planID).Return(nil)
+	mockPlanRepo.On("GetByID", mock.Anything, planID).Return(&domain.Plan{ID: planID, Name: "Basic"}, nil)
+
+	planUseCase := usecases.NewPlanUseCase(mockPlanRepo)
+	result, err := planUseCase.RestorePlan(context.Background(), planID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, planID, result.ID)
+	mockPlanRepo.AssertExpectations(t)
+}
+
+func TestRestorePlan_NotInTrash(t *testing.T) {
+	mockPlanRepo := new(MockPlanRepository)
+
+	planID := uuid.New()
+	mockPlanRepo.On("Restore", mock.Anything, planID).Return(domain.ErrPlanNotFound)
+
+	planUseCase := usecases.NewPlanUseCase(mockPlanRepo)
+	result, err := planUseCase.RestorePlan(context.Background(), planID)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrPlanNotFound, err)
+	mockPlanRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
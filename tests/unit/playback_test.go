@@ -0,0 +1,226 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/signedurl"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testVideoURL = "https://cdn.example.com/videos/inception/master.mp4"
+
+func newPlaybackUseCase(contentRepo *MockContentRepository, renditionRepo *MockRenditionRepository, subRepo *MockSubscriptionRepository) *usecases.PlaybackUseCase {
+	contentUseCase := usecases.NewContentUseCase(contentRepo, subRepo, new(MockUserRepository), new(MockContentRevisionRepository))
+	trackRepo := new(MockTrackRepository)
+	trackRepo.On("ListSubtitles", mock.Anything, mock.Anything).Return([]*domain.SubtitleTrack{}, nil)
+	trackRepo.On("ListAudioTracks", mock.Anything, mock.Anything).Return([]*domain.AudioTrack{}, nil)
+	return usecases.NewPlaybackUseCase(contentUseCase, renditionRepo, trackRepo, subRepo, signedurl.NewSigner([]byte("test-key")), time.Hour)
+}
+
+func activeSubscription(userID uuid.UUID, level domain.AccessLevel, resolution domain.Resolution) *domain.Subscription {
+	return &domain.Subscription{
+		UserID:   userID,
+		IsActive: true,
+		EndDate:  time.Now().Add(24 * time.Hour),
+		Plan:     &domain.Plan{AccessLevel: level, Resolution: resolution},
+	}
+}
+
+func TestSignedURL_RoundTrip(t *testing.T) {
+	signer := signedurl.NewSigner([]byte("test-key"))
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	signed, err := signer.Sign(testVideoURL, signedurl.Claims{UserID: "user-1", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	claims, err := signer.Verify(signed, "203.0.113.7", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.True(t, expiresAt.Equal(claims.ExpiresAt))
+}
+
+func TestSignedURL_RejectsTampering(t *testing.T) {
+	signer := signedurl.NewSigner([]byte("test-key"))
+	signed, err := signer.Sign(testVideoURL, signedurl.Claims{UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = signer.Verify(strings.Replace(signed, "uid=user-1", "uid=user-2", 1), "", time.Now())
+	assert.Equal(t, signedurl.ErrInvalidSignature, err)
+
+	_, err = signer.Verify(strings.Replace(signed, "inception", "interstellar", 1), "", time.Now())
+	assert.Equal(t, signedurl.ErrInvalidSignature, err)
+
+	_, err = signedurl.NewSigner([]byte("other-key")).Verify(signed, "", time.Now())
+	assert.Equal(t, signedurl.ErrInvalidSignature, err)
+
+	_, err = signer.Verify(testVideoURL, "", time.Now())
+	assert.Equal(t, signedurl.ErrMissingSignature, err)
+}
+
+func TestSignedURL_Expired(t *testing.T) {
+	signer := signedurl.NewSigner([]byte("test-key"))
+	signed, err := signer.Sign(testVideoURL, signedurl.Claims{UserID: "user-1", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+
+	_, err = signer.Verify(signed, "", time.Now().Add(2*time.Minute))
+	assert.Equal(t, signedurl.ErrExpired, err)
+}
+
+func TestSignedURL_IPBinding(t *testing.T) {
+	signer := signedurl.NewSigner([]byte("test-key"))
+	signed, err := signer.Sign(testVideoURL, signedurl.Claims{UserID: "user-1", ClientIP: "203.0.113.7", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = signer.Verify(signed, "203.0.113.7", time.Now())
+	assert.NoError(t, err)
+
+	_, err = signer.Verify(signed, "198.51.100.1", time.Now())
+	assert.Equal(t, signedurl.ErrIPMismatch, err)
+}
+
+func TestCreatePlaybackSession_Success(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{
+		ID:          contentID,
+		AccessLevel: domain.AccessLevelPremium,
+		Published:   true,
+		VideoURL:    testVideoURL,
+	}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(activeSubscription(userID, domain.AccessLevelPremium, domain.Resolution4K), nil)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return([]*domain.VideoRendition{}, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, userID, "", "203.0.113.7", usecases.PlaybackInput{BindIP: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, contentID, session.ContentID)
+	assert.True(t, strings.HasPrefix(session.URL, testVideoURL+"?"))
+
+	claims, err := signedurl.NewSigner([]byte("test-key")).Verify(session.URL, "203.0.113.7", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, userID.String(), claims.UserID)
+	assert.Equal(t, "203.0.113.7", claims.ClientIP)
+}
+
+func TestCreatePlaybackSession_NoEntitlement(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelPremium, Published: true, VideoURL: testVideoURL}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, userID, "", "203.0.113.7", usecases.PlaybackInput{})
+
+	assert.Nil(t, session)
+	assert.Equal(t, domain.ErrContentNotAccessible, err)
+}
+
+func TestCreatePlaybackSession_NoVideo(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, Published: true}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, mock.Anything).Return(nil, domain.ErrSubscriptionNotFound)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return([]*domain.VideoRendition{}, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, uuid.New(), "", "", usecases.PlaybackInput{})
+
+	assert.Nil(t, session)
+	assert.Equal(t, domain.ErrPlaybackUnavailable, err)
+}
+
+func TestCreatePlaybackSession_CapsRenditionsAtPlanResolution(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelBasic, Published: true, VideoURL: testVideoURL}
+	renditions := []*domain.VideoRendition{
+		{ContentID: contentID, Resolution: domain.Resolution480p, BitrateKbps: 1200, Codec: "avc1", URL: "https://cdn.example.com/v/480.mp4"},
+		{ContentID: contentID, Resolution: domain.Resolution720p, BitrateKbps: 3000, Codec: "avc1", URL: "https://cdn.example.com/v/720.mp4"},
+		{ContentID: contentID, Resolution: domain.Resolution1080p, BitrateKbps: 6000, Codec: "avc1", URL: "https://cdn.example.com/v/1080.mp4"},
+		{ContentID: contentID, Resolution: domain.Resolution4K, BitrateKbps: 16000, Codec: "hvc1", URL: "https://cdn.example.com/v/2160.mp4"},
+	}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(activeSubscription(userID, domain.AccessLevelBasic, domain.Resolution720p), nil)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return(renditions, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, userID, "", "", usecases.PlaybackInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.Resolution720p, session.MaxResolution)
+	require.Len(t, session.Renditions, 2)
+	assert.Equal(t, domain.Resolution480p, session.Renditions[0].Resolution)
+	assert.Equal(t, domain.Resolution720p, session.Renditions[1].Resolution)
+	assert.True(t, strings.HasPrefix(session.URL, "https://cdn.example.com/v/720.mp4?"))
+	assert.NotContains(t, session.URL, "master.mp4")
+}
+
+func TestCreatePlaybackSession_NoRenditionWithinCap(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, Published: true, VideoURL: testVideoURL}
+	renditions := []*domain.VideoRendition{
+		{ContentID: contentID, Resolution: domain.Resolution4K, BitrateKbps: 16000, Codec: "hvc1", URL: "https://cdn.example.com/v/2160.mp4"},
+	}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return(renditions, nil)
+
+	playbackUseCase := newPlaybackUseCase(mockContentRepo, mockRenditionRepo, mockSubRepo)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, userID, "", "", usecases.PlaybackInput{})
+
+	assert.Nil(t, session)
+	assert.Equal(t, domain.ErrPlaybackUnavailable, err)
+}
+
+func TestResolution_AtMost(t *testing.T) {
+	assert.True(t, domain.Resolution720p.AtMost(domain.Resolution1080p))
+	assert.True(t, domain.Resolution4K.AtMost(domain.Resolution4K))
+	assert.False(t, domain.Resolution4K.AtMost(domain.Resolution1080p))
+	assert.False(t, domain.Resolution("8K").AtMost(domain.Resolution4K))
+}
+
+func TestPlan_MaxResolution(t *testing.T) {
+	resolution, err := (&domain.Plan{Resolution: "4k"}).MaxResolution()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Resolution4K, resolution)
+
+	resolution, err = (&domain.Plan{}).MaxResolution()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Resolution480p, resolution)
+
+	_, err = (&domain.Plan{Resolution: "HD"}).MaxResolution()
+	assert.ErrorIs(t, err, domain.ErrInvalidResolution)
+}
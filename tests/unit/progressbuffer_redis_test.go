@@ -0,0 +1,111 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/config"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRedisProgressBuffer runs the real buffer, Lua scripts included, against
+// an in-process Redis
+func newRedisProgressBuffer(t *testing.T) (*infrastructure.ProgressBuffer, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	cache := infrastructure.NewCache(&config.Config{RedisHost: server.Host(), RedisPort: server.Port()})
+	t.Cleanup(func() { cache.Close() })
+	return infrastructure.NewProgressBuffer(cache), server
+}
+
+func bufferedHistory(userID uuid.UUID, watchedSeconds int) *domain.WatchHistory {
+	credits := 6800
+	until := time.Now().Add(30 * 24 * time.Hour)
+	content := &domain.Content{
+		ID:               uuid.New(),
+		Title:            "Heat",
+		AccessLevel:      domain.AccessLevelPremium,
+		DurationSeconds:  7200,
+		AvailableUntil:   &until,
+		AllowedCountries: domain.CountryCodes{"US", "CA"},
+		Markers:          domain.ContentMarkers{CreditsStartSeconds: &credits, Chapters: domain.Chapters{{Title: "Opening", StartSeconds: 0}}},
+	}
+	return &domain.WatchHistory{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ContentID:      content.ID,
+		Content:        content,
+		WatchedSeconds: watchedSeconds,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusStarted,
+		Country:        "US",
+		LastWatchedAt:  time.Now(),
+	}
+}
+
+func TestProgressBuffer_AckRemovesDrainedEntries(t *testing.T) {
+	ctx := context.Background()
+	buffer, server := newRedisProgressBuffer(t)
+	userID := uuid.New()
+	require.NoError(t, buffer.Put(ctx, bufferedHistory(userID, 120)))
+	require.NoError(t, buffer.Put(ctx, bufferedHistory(userID, 300)))
+
+	drained, err := buffer.Drain(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, drained, 2)
+
+	require.NoError(t, buffer.Ack(ctx, drained))
+	listed, err := buffer.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	assert.False(t, server.Exists("watch_progress:dirty"))
+}
+
+func TestProgressBuffer_AckKeepsEntriesOverwrittenSinceDrain(t *testing.T) {
+	ctx := context.Background()
+	buffer, server := newRedisProgressBuffer(t)
+	userID := uuid.New()
+	history := bufferedHistory(userID, 120)
+	require.NoError(t, buffer.Put(ctx, history))
+
+	drained, err := buffer.Drain(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, drained, 1)
+
+	// A heartbeat lands while the drained batch is being written
+	history.WatchedSeconds = 125
+	history.LastWatchedAt = time.Now()
+	require.NoError(t, buffer.Put(ctx, history))
+	require.NoError(t, buffer.Ack(ctx, drained))
+
+	buffered, err := buffer.Get(ctx, userID, history.ContentID)
+	require.NoError(t, err)
+	assert.Equal(t, 125, buffered.WatchedSeconds)
+	members, err := server.Members("watch_progress:dirty")
+	require.NoError(t, err)
+	assert.Equal(t, []string{userID.String()}, members)
+
+	drained, err = buffer.Drain(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, drained, 1)
+	assert.Equal(t, 125, drained[0].WatchedSeconds)
+}
+
+func TestProgressBuffer_DrainForgetsUsersWithoutEntries(t *testing.T) {
+	ctx := context.Background()
+	buffer, server := newRedisProgressBuffer(t)
+	userID := uuid.New()
+	_, err := server.SAdd("watch_progress:dirty", userID.String())
+	require.NoError(t, err)
+
+	drained, err := buffer.Drain(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, drained)
+	assert.False(t, server.Exists("watch_progress:dirty"))
+}
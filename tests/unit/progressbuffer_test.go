@@ -0,0 +1,344 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeProgressBuffer is an in-memory stand-in for the Redis progress buffer,
+// storing entries as JSON like the real one
+type fakeProgressBuffer struct {
+	mu      sync.Mutex
+	entries map[uuid.UUID]map[uuid.UUID]string
+}
+
+func newFakeProgressBuffer() *fakeProgressBuffer {
+	return &fakeProgressBuffer{entries: make(map[uuid.UUID]map[uuid.UUID]string)}
+}
+
+func (b *fakeProgressBuffer) Put(ctx context.Context, history *domain.WatchHistory) error {
+	value, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.entries[history.UserID] == nil {
+		b.entries[history.UserID] = make(map[uuid.UUID]string)
+	}
+	b.entries[history.UserID][history.ContentID] = string(value)
+	return nil
+}
+
+func (b *fakeProgressBuffer) Get(ctx context.Context, userID, contentID uuid.UUID) (*domain.WatchHistory, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	value, ok := b.entries[userID][contentID]
+	if !ok {
+		return nil, domain.ErrWatchHistoryNotFound
+	}
+	var history domain.WatchHistory
+	err := json.Unmarshal([]byte(value), &history)
+	return &history, err
+}
+
+func (b *fakeProgressBuffer) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WatchHistory, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var histories []*domain.WatchHistory
+	for _, value := range b.entries[userID] {
+		var history domain.WatchHistory
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			return nil, err
+		}
+		histories = append(histories, &history)
+	}
+	return histories, nil
+}
+
+func (b *fakeProgressBuffer) Drain(ctx context.Context, maxUsers int) ([]*domain.WatchHistory, error) {
+	var histories []*domain.WatchHistory
+	users := 0
+	for userID := range b.entries {
+		if users == maxUsers {
+			break
+		}
+		buffered, err := b.ListByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, buffered...)
+		users++
+	}
+	return histories, nil
+}
+
+func (b *fakeProgressBuffer) Ack(ctx context.Context, histories []*domain.WatchHistory) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, history := range histories {
+		value, err := json.Marshal(history)
+		if err != nil {
+			return err
+		}
+		if b.entries[history.UserID][history.ContentID] == string(value) {
+			delete(b.entries[history.UserID], history.ContentID)
+		}
+		if len(b.entries[history.UserID]) == 0 {
+			delete(b.entries, history.UserID)
+		}
+	}
+	return nil
+}
+
+func (b *fakeProgressBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, entries := range b.entries {
+		n += len(entries)
+	}
+	return n
+}
+
+func TestCreateOrUpdateWatchHistory_BuffersHeartbeats(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	buffer := newFakeProgressBuffer()
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 3600}
+	stored := &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: content.ID, TotalSeconds: 3600, Status: domain.WatchStatusPaused}
+
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil).Times(3)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(stored, nil).Once()
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, buffer)
+	for _, watched := range []int{100, 105, 110} {
+		result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "US", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+		assert.NoError(t, err)
+		assert.Equal(t, watched, result.WatchedSeconds)
+		assert.Equal(t, stored.ID, result.ID)
+	}
+
+	buffered, err := buffer.Get(context.Background(), userID, content.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 110, buffered.WatchedSeconds)
+	assert.Equal(t, "US", buffered.Country)
+	mockWatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockContentRepo.AssertExpectations(t)
+	mockWatchRepo.AssertExpectations(t)
+}
+
+func TestCreateOrUpdateWatchHistory_BufferedTitleRechecksAccess(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	buffer := newFakeProgressBuffer()
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelPremium, DurationSeconds: 3600, AllowedCountries: domain.CountryCodes{"US"}}
+	history := &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: content.ID, TotalSeconds: 3600, WatchedSeconds: 100, Status: domain.WatchStatusStarted, Content: content}
+	assert.NoError(t, buffer.Put(context.Background(), history))
+
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, buffer)
+	watched := 200
+	input := usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched}
+
+	_, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "FR", input)
+	assert.Equal(t, domain.ErrContentNotAvailableInRegion, err)
+
+	_, err = watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "US", input)
+	assert.Equal(t, domain.ErrContentNotAccessible, err)
+
+	buffered, err := buffer.Get(context.Background(), userID, content.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, buffered.WatchedSeconds)
+	mockWatchRepo.AssertNotCalled(t, "GetByUserAndContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrUpdateWatchHistory_NewTitleWrittenThrough(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	buffer := newFakeProgressBuffer()
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 3600}
+
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(nil, domain.ErrWatchHistoryNotFound)
+	mockWatchRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.WatchHistory")).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, buffer)
+	watched := 30
+	_, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, buffer.Len())
+	mockWatchRepo.AssertExpectations(t)
+}
+
+func TestUpdateProgress_ContinuesFromBufferedProgress(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	buffer := newFakeProgressBuffer()
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 3600}
+	stored := &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: content.ID, Content: content, TotalSeconds: 3600, Status: domain.WatchStatusStarted}
+	assert.NoError(t, buffer.Put(context.Background(), &domain.WatchHistory{
+		ID: stored.ID, UserID: userID, ContentID: content.ID, TotalSeconds: 3600,
+		WatchedSeconds: 3500, Status: domain.WatchStatusCompleted, Content: content,
+	}))
+
+	mockWatchRepo.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, buffer)
+	result, err := watchUseCase.UpdateProgress(context.Background(), userID, stored.ID, "", 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusStarted, result.Status)
+	assert.Equal(t, 1, result.RewatchCount)
+	mockWatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestGetContinueWatching_MergesBufferedProgress(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	buffer := newFakeProgressBuffer()
+
+	userID := uuid.New()
+	now := time.Now().UTC()
+	paused := func(content *domain.Content, watched int, at time.Time) *domain.WatchHistory {
+		return &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: content.ID, Content: content,
+			WatchedSeconds: watched, TotalSeconds: 3600, Status: domain.WatchStatusPaused, LastWatchedAt: at}
+	}
+	movie := &domain.Content{ID: uuid.New(), Title: "Movie"}
+	series := &domain.Content{ID: uuid.New(), Title: "Series"}
+	short := &domain.Content{ID: uuid.New(), Title: "Short"}
+
+	stored := []*domain.WatchHistory{paused(movie, 100, now.Add(-2*time.Hour)), paused(series, 900, now.Add(-time.Hour))}
+	mockWatchRepo.On("GetContinueWatching", mock.Anything, userID, 10).Return(stored, nil)
+
+	newer := paused(movie, 1500, now)
+	finished := paused(series, 3600, now.Add(-time.Minute))
+	finished.Status = domain.WatchStatusCompleted
+	outside := paused(short, 40, now.Add(-30*time.Minute))
+	for _, history := range []*domain.WatchHistory{newer, finished, outside} {
+		assert.NoError(t, buffer.Put(context.Background(), history))
+	}
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, new(MockContentRepository), new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, buffer)
+	result, err := watchUseCase.GetContinueWatching(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, movie.ID, result[0].ContentID)
+	assert.Equal(t, 1500, result[0].WatchedSeconds)
+	assert.Equal(t, short.ID, result[1].ContentID)
+}
+
+func TestProgressFlushJob_WritesBatchesAndAcknowledges(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	buffer := newFakeProgressBuffer()
+	for i := 0; i < 3; i++ {
+		userID := uuid.New()
+		for j := 0; j < 2; j++ {
+			assert.NoError(t, buffer.Put(context.Background(), &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: uuid.New(), WatchedSeconds: 60}))
+		}
+	}
+
+	mockWatchRepo.On("UpdateProgressBatch", mock.Anything, mock.MatchedBy(func(histories []*domain.WatchHistory) bool {
+		return len(histories) <= 4
+	})).Return(nil).Twice()
+
+	job := usecases.NewProgressFlushJob(buffer, mockWatchRepo, 2)
+	assert.NoError(t, job.Run(context.Background()))
+	assert.Equal(t, 0, buffer.Len())
+	mockWatchRepo.AssertExpectations(t)
+}
+
+func TestProgressFlushJob_KeepsEntriesWhenWriteFails(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	buffer := newFakeProgressBuffer()
+	assert.NoError(t, buffer.Put(context.Background(), &domain.WatchHistory{ID: uuid.New(), UserID: uuid.New(), ContentID: uuid.New(), WatchedSeconds: 60}))
+
+	mockWatchRepo.On("UpdateProgressBatch", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+	job := usecases.NewProgressFlushJob(buffer, mockWatchRepo, 100)
+	assert.Error(t, job.Run(context.Background()))
+	assert.Equal(t, 1, buffer.Len())
+}
+
+// benchmarkHeartbeats plays heartbeats for a set of viewers every 5 seconds
+// and runs the flush job every 30 seconds of simulated time, reporting the
+// database calls made per heartbeat
+func benchmarkHeartbeats(b *testing.B, buffered bool) {
+	const viewers = 50
+	const heartbeatsPerFlush = viewers * 30 / 5
+
+	var reads, writes int
+	countReads := func(mock.Arguments) { reads++ }
+	countWrites := func(mock.Arguments) { writes++ }
+
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelPremium, DurationSeconds: 7200}
+	subscription := &domain.Subscription{EndDate: time.Now().Add(time.Hour)}
+	userIDs := make([]uuid.UUID, viewers)
+	for i := range userIDs {
+		userIDs[i] = uuid.New()
+		history := &domain.WatchHistory{ID: uuid.New(), UserID: userIDs[i], ContentID: content.ID, TotalSeconds: 7200, Status: domain.WatchStatusPaused}
+		mockWatchRepo.On("GetByUserAndContent", mock.Anything, userIDs[i], content.ID).Return(history, nil).Run(countReads)
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil).Run(countReads)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, mock.Anything).Return(subscription, nil).Run(countReads)
+	mockWatchRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Run(countWrites)
+	mockWatchRepo.On("UpdateProgressBatch", mock.Anything, mock.Anything).Return(nil).Run(countWrites)
+
+	var watchUseCase *usecases.WatchHistoryUseCase
+	var job *usecases.ProgressFlushJob
+	if buffered {
+		buffer := newFakeProgressBuffer()
+		watchUseCase = usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, buffer)
+		job = usecases.NewProgressFlushJob(buffer, mockWatchRepo, viewers)
+	} else {
+		watchUseCase = usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+	}
+
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		watched := 60 + (i/viewers)*5
+		input := usecases.WatchHistoryInput{ContentID: content.ID, WatchedSeconds: &watched}
+		if _, err := watchUseCase.CreateOrUpdateWatchHistory(ctx, userIDs[i%viewers], "", input); err != nil {
+			b.Fatal(err)
+		}
+		if job != nil && (i+1)%heartbeatsPerFlush == 0 {
+			if err := job.Run(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(writes)/float64(b.N), "db-writes/op")
+	b.ReportMetric(float64(reads)/float64(b.N), "db-reads/op")
+}
+
+func BenchmarkHeartbeats_WriteThrough(b *testing.B) { benchmarkHeartbeats(b, false) }
+
+func BenchmarkHeartbeats_WriteBehind(b *testing.B) { benchmarkHeartbeats(b, true) }
@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationRepository) RebuildSimilarities(ctx context.Context, minCoViewers, perContent int) (int64, error) {
+	args := m.Called(ctx, minCoViewers, perContent)
+	return int64(args.Int(0)), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) ListSimilar(ctx context.Context, contentIDs []uuid.UUID, perContent int) ([]*domain.ContentSimilarity, error) {
+	args := m.Called(ctx, contentIDs, perContent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentSimilarity), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) ListPopular(ctx context.Context, since time.Time, limit int) ([]*domain.Content, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Content), args.Error(1)
+}
+
+func uncachedRecommendations() *MockCache {
+	mockCache := new(MockCache)
+	mockCache.On("Get", mock.Anything, mock.Anything).Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return mockCache
+}
+
+func TestGetRecommendations_RanksSimilarAndFiltersAccess(t *testing.T) {
+	mockRecRepo := new(MockRecommendationRepository)
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	userID := uuid.New()
+
+	seed := &domain.Content{ID: uuid.New(), Title: "Seed", Published: true}
+	near := &domain.Content{ID: uuid.New(), Published: true, AccessLevel: domain.AccessLevelFree}
+	far := &domain.Content{ID: uuid.New(), Published: true, AccessLevel: domain.AccessLevelFree}
+	premium := &domain.Content{ID: uuid.New(), Published: true, AccessLevel: domain.AccessLevelPremium}
+	finished := &domain.Content{ID: uuid.New(), Published: true, AccessLevel: domain.AccessLevelFree}
+
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockWatchRepo.On("GetByUserID", mock.Anything, userID, mock.Anything).Return([]*domain.WatchHistory{
+		{ContentID: seed.ID, Content: seed, Status: domain.WatchStatusCompleted},
+	}, int64(1), nil)
+	mockRecRepo.On("ListSimilar", mock.Anything, []uuid.UUID{seed.ID}, mock.Anything).Return([]*domain.ContentSimilarity{
+		{ContentID: seed.ID, SimilarContentID: premium.ID, SimilarContent: premium, Score: 0.9},
+		{ContentID: seed.ID, SimilarContentID: finished.ID, SimilarContent: finished, Score: 0.8},
+		{ContentID: seed.ID, SimilarContentID: near.ID, SimilarContent: near, Score: 0.7},
+		{ContentID: seed.ID, SimilarContentID: far.ID, SimilarContent: far, Score: 0.2},
+	}, nil)
+	mockWatchRepo.On("GetByUserAndContentIDs", mock.Anything, userID, mock.Anything).Return([]*domain.WatchHistory{
+		{ContentID: finished.ID, Status: domain.WatchStatusCompleted},
+	}, nil)
+
+	uc := usecases.NewRecommendationUseCase(mockRecRepo, mockWatchRepo, mockSubRepo, uncachedRecommendations())
+	recommendations, err := uc.GetRecommendations(context.Background(), userID, "US", 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 2)
+	assert.Equal(t, near.ID, recommendations[0].Content.ID)
+	assert.Equal(t, usecases.RecommendationReasonSimilar, recommendations[0].Reason)
+	assert.Equal(t, "Seed", recommendations[0].BecauseYouWatched.Title)
+	assert.Equal(t, far.ID, recommendations[1].Content.ID)
+	mockRecRepo.AssertNotCalled(t, "ListPopular", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetRecommendations_ColdStartFallsBackToPopular(t *testing.T) {
+	mockRecRepo := new(MockRecommendationRepository)
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+	userID := uuid.New()
+
+	hit := &domain.Content{ID: uuid.New(), Published: true, AccessLevel: domain.AccessLevelFree}
+	unpublished := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree}
+
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockWatchRepo.On("GetByUserID", mock.Anything, userID, mock.Anything).Return([]*domain.WatchHistory{}, int64(0), nil)
+	mockRecRepo.On("ListSimilar", mock.Anything, []uuid.UUID{}, mock.Anything).Return([]*domain.ContentSimilarity{}, nil)
+	mockRecRepo.On("ListPopular", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Content{unpublished, hit}, nil)
+	mockWatchRepo.On("GetByUserAndContentIDs", mock.Anything, userID, []uuid.UUID{hit.ID}).Return([]*domain.WatchHistory{}, nil)
+
+	uc := usecases.NewRecommendationUseCase(mockRecRepo, mockWatchRepo, mockSubRepo, uncachedRecommendations())
+	recommendations, err := uc.GetRecommendations(context.Background(), userID, "US", 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	assert.Equal(t, hit.ID, recommendations[0].Content.ID)
+	assert.Equal(t, usecases.RecommendationReasonPopular, recommendations[0].Reason)
+	assert.Nil(t, recommendations[0].BecauseYouWatched)
+}
+
+func TestGetRecommendations_ServesFromCache(t *testing.T) {
+	mockSubRepo := new(MockSubscriptionRepository)
+	mockCache := new(MockCache)
+	userID := uuid.New()
+
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockCache.On("Get", mock.Anything, "recommendations:generation").Return("7", nil)
+	mockCache.On("Get", mock.Anything, "recommendations:7:"+userID.String()+":free:US:20").
+		Return(`[{"content":{"title":"Cached"},"score":1,"reason":"popular"}]`, nil)
+
+	uc := usecases.NewRecommendationUseCase(new(MockRecommendationRepository), new(MockWatchHistoryRepository), mockSubRepo, mockCache)
+	recommendations, err := uc.GetRecommendations(context.Background(), userID, "US", 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	assert.Equal(t, "Cached", recommendations[0].Content.Title)
+}
+
+func TestRecommendationJob_RunBumpsCacheGeneration(t *testing.T) {
+	mockRecRepo := new(MockRecommendationRepository)
+	mockCache := new(MockCache)
+
+	mockRecRepo.On("RebuildSimilarities", mock.Anything, mock.Anything, mock.Anything).Return(12, nil)
+	mockCache.On("Increment", mock.Anything, "recommendations:generation").Return(3, nil)
+
+	err := usecases.NewRecommendationJob(mockRecRepo, mockCache).Run(context.Background())
+
+	assert.NoError(t, err)
+	mockRecRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestRecommendations_ContentEventBumpsCacheGeneration(t *testing.T) {
+	mockCache := new(MockCache)
+	mockCache.On("Increment", mock.Anything, "recommendations:generation").Return(8, nil)
+
+	uc := usecases.NewRecommendationUseCase(new(MockRecommendationRepository), new(MockWatchHistoryRepository), new(MockSubscriptionRepository), mockCache)
+	uc.HandleContentEvent(context.Background(), domain.ContentEvent{Type: domain.ContentEventPublished, ContentID: uuid.New()})
+
+	mockCache.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRenditionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRenditionRepository) Create(ctx context.Context, rendition *domain.VideoRendition) error {
+	args := m.Called(ctx, rendition)
+	return args.Error(0)
+}
+
+func (m *MockRenditionRepository) GetByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.VideoRendition, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.VideoRendition), args.Error(1)
+}
+
+func (m *MockRenditionRepository) Delete(ctx context.Context, contentID, id uuid.UUID) error {
+	args := m.Called(ctx, contentID, id)
+	return args.Error(0)
+}
+
+func TestAddRendition_Success(t *testing.T) {
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return([]*domain.VideoRendition{}, nil)
+	mockRenditionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.VideoRendition")).Return(nil)
+
+	renditionUseCase := usecases.NewRenditionUseCase(mockRenditionRepo, mockContentRepo)
+	rendition, err := renditionUseCase.AddRendition(context.Background(), contentID, usecases.CreateRenditionInput{
+		Resolution:  domain.Resolution1080p,
+		BitrateKbps: 6000,
+		Codec:       "avc1.640028",
+		URL:         "https://cdn.example.com/v/1080.mp4",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, contentID, rendition.ContentID)
+	assert.Equal(t, domain.Resolution1080p, rendition.Resolution)
+	mockRenditionRepo.AssertExpectations(t)
+}
+
+func TestAddRendition_Duplicate(t *testing.T) {
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	existing := []*domain.VideoRendition{{ContentID: contentID, Resolution: domain.Resolution720p, Codec: "avc1"}}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return(existing, nil)
+
+	renditionUseCase := usecases.NewRenditionUseCase(mockRenditionRepo, mockContentRepo)
+	rendition, err := renditionUseCase.AddRendition(context.Background(), contentID, usecases.CreateRenditionInput{
+		Resolution:  domain.Resolution720p,
+		BitrateKbps: 3000,
+		Codec:       "avc1",
+		URL:         "https://cdn.example.com/v/720.mp4",
+	})
+
+	assert.Nil(t, rendition)
+	assert.Equal(t, domain.ErrRenditionExists, err)
+	mockRenditionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAddRendition_InvalidResolution(t *testing.T) {
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	renditionUseCase := usecases.NewRenditionUseCase(mockRenditionRepo, mockContentRepo)
+	rendition, err := renditionUseCase.AddRendition(context.Background(), uuid.New(), usecases.CreateRenditionInput{
+		Resolution: "HD",
+	})
+
+	assert.Nil(t, rendition)
+	assert.Equal(t, domain.ErrInvalidResolution, err)
+}
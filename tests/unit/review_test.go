@@ -0,0 +1,142 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) Upsert(ctx context.Context, review *domain.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Review, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (m *MockReviewRepository) GetByUserAndContent(ctx context.Context, userID, contentID uuid.UUID) (*domain.Review, error) {
+	args := m.Called(ctx, userID, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (m *MockReviewRepository) ListPublished(ctx context.Context, contentID uuid.UUID, limit, offset int) ([]*domain.Review, int64, error) {
+	args := m.Called(ctx, contentID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Review), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReviewRepository) ListByStatus(ctx context.Context, status domain.ReviewStatus, limit, offset int) ([]*domain.Review, int64, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Review), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReviewRepository) Update(ctx context.Context, review *domain.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestRateContent_RequiresWatchHistory(t *testing.T) {
+	mockReviewRepo := new(MockReviewRepository)
+	mockWatchHistoryRepo := new(MockWatchHistoryRepository)
+
+	userID, contentID := uuid.New(), uuid.New()
+	mockWatchHistoryRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(nil, domain.ErrWatchHistoryNotFound)
+
+	reviewUseCase := usecases.NewReviewUseCase(mockReviewRepo, mockWatchHistoryRepo)
+	review, err := reviewUseCase.RateContent(context.Background(), userID, contentID, usecases.ReviewInput{Rating: 5})
+
+	assert.Nil(t, review)
+	assert.Equal(t, domain.ErrRatingRequiresWatch, err)
+	mockReviewRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestRateContent_ModerationState(t *testing.T) {
+	mockReviewRepo := new(MockReviewRepository)
+	mockWatchHistoryRepo := new(MockWatchHistoryRepository)
+
+	userID, contentID := uuid.New(), uuid.New()
+	mockWatchHistoryRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(&domain.WatchHistory{UserID: userID, ContentID: contentID}, nil)
+	mockReviewRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(nil, domain.ErrReviewNotFound).Once()
+	mockReviewRepo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.Review")).Return(nil)
+
+	reviewUseCase := usecases.NewReviewUseCase(mockReviewRepo, mockWatchHistoryRepo)
+
+	bare, err := reviewUseCase.RateContent(context.Background(), userID, contentID, usecases.ReviewInput{Rating: 4})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, bare.Status)
+
+	mockReviewRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(bare, nil)
+	withText, err := reviewUseCase.RateContent(context.Background(), userID, contentID, usecases.ReviewInput{Rating: 2, Body: "  Slow second half.  "})
+	require.NoError(t, err)
+	assert.Equal(t, bare.ID, withText.ID)
+	assert.Equal(t, 2, withText.Rating)
+	assert.Equal(t, "Slow second half.", withText.Body)
+	assert.Equal(t, domain.ReviewStatusPending, withText.Status)
+}
+
+func TestRateContent_UnchangedTextKeepsApproval(t *testing.T) {
+	mockReviewRepo := new(MockReviewRepository)
+	mockWatchHistoryRepo := new(MockWatchHistoryRepository)
+
+	userID, contentID := uuid.New(), uuid.New()
+	existing := &domain.Review{ID: uuid.New(), UserID: userID, ContentID: contentID, Rating: 3, Body: "Great cast", Status: domain.ReviewStatusApproved}
+	mockWatchHistoryRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(&domain.WatchHistory{}, nil)
+	mockReviewRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(existing, nil)
+	mockReviewRepo.On("Upsert", mock.Anything, existing).Return(nil)
+
+	reviewUseCase := usecases.NewReviewUseCase(mockReviewRepo, mockWatchHistoryRepo)
+	review, err := reviewUseCase.RateContent(context.Background(), userID, contentID, usecases.ReviewInput{Rating: 5, Body: "Great cast"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, review.Rating)
+	assert.Equal(t, domain.ReviewStatusApproved, review.Status)
+}
+
+func TestModerateReview(t *testing.T) {
+	mockReviewRepo := new(MockReviewRepository)
+	mockWatchHistoryRepo := new(MockWatchHistoryRepository)
+
+	moderatorID, reviewID := uuid.New(), uuid.New()
+	mockReviewRepo.On("GetByID", mock.Anything, reviewID).Return(&domain.Review{ID: reviewID, Status: domain.ReviewStatusPending}, nil)
+	mockReviewRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Review")).Return(nil)
+
+	reviewUseCase := usecases.NewReviewUseCase(mockReviewRepo, mockWatchHistoryRepo)
+
+	_, err := reviewUseCase.ModerateReview(context.Background(), moderatorID, reviewID, usecases.ModerateReviewInput{Status: domain.ReviewStatusPending})
+	assert.Equal(t, domain.ErrInvalidReviewStatus, err)
+
+	review, err := reviewUseCase.ModerateReview(context.Background(), moderatorID, reviewID, usecases.ModerateReviewInput{Status: domain.ReviewStatusRejected, Note: "spoilers"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusRejected, review.Status)
+	assert.Equal(t, moderatorID, *review.ModeratedBy)
+	assert.NotNil(t, review.ModeratedAt)
+}
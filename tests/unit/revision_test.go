@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockContentRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockContentRevisionRepository) Record(ctx context.Context, content *domain.Content, revision *domain.ContentRevision, discardDraft bool) error {
+	args := m.Called(ctx, content, revision, discardDraft)
+	return args.Error(0)
+}
+
+func (m *MockContentRevisionRepository) SaveDraft(ctx context.Context, revision *domain.ContentRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *MockContentRevisionRepository) GetDraft(ctx context.Context, contentID uuid.UUID) (*domain.ContentRevision, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ContentRevision), args.Error(1)
+}
+
+func (m *MockContentRevisionRepository) GetByNumber(ctx context.Context, contentID uuid.UUID, number int) (*domain.ContentRevision, error) {
+	args := m.Called(ctx, contentID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ContentRevision), args.Error(1)
+}
+
+func (m *MockContentRevisionRepository) ListByContentID(ctx context.Context, contentID uuid.UUID) ([]*domain.ContentRevision, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentRevision), args.Error(1)
+}
+
+func (m *MockContentRevisionRepository) DeleteDraft(ctx context.Context, contentID uuid.UUID) error {
+	args := m.Called(ctx, contentID)
+	return args.Error(0)
+}
+
+func revisionTestContent() *domain.Content {
+	return &domain.Content{
+		ID:              uuid.New(),
+		Title:           "Original",
+		Description:     "First cut",
+		AccessLevel:     domain.AccessLevelFree,
+		DurationSeconds: 3600,
+		Published:       true,
+	}
+}
+
+func TestContentSnapshot_Diff(t *testing.T) {
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	sameInstant := at.UTC()
+	before := domain.ContentSnapshot{Title: "Old", DurationSeconds: 60, AvailableFrom: &at, AllowedCountries: domain.CountryCodes{}}
+	after := domain.ContentSnapshot{Title: "New", DurationSeconds: 60, AvailableFrom: &sameInstant}
+
+	changes := before.Diff(after)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, domain.FieldChange{From: "Old", To: "New"}, changes["title"])
+}
+
+func TestUpdateContent_RecordsOnlyChangedFields(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	content := revisionTestContent()
+	authorID := uuid.New()
+
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockRevisionRepo.On("Record", mock.Anything, content, mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		_, descriptionChanged := revision.Changes["description"]
+		return revision.AuthorID == authorID && len(revision.Changes) == 2 && descriptionChanged && revision.Changes["title"].From == "Original"
+	}), false).Return(nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	result, err := uc.UpdateContent(context.Background(), authorID, content.ID, usecases.CreateContentInput{
+		Title:           "Director's Cut",
+		Description:     "Extended",
+		AccessLevel:     domain.AccessLevelFree,
+		DurationSeconds: 3600,
+		Published:       true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Director's Cut", result.Title)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestUpdateContent_NoChangesRecordsNothing(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	content := revisionTestContent()
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	_, err := uc.UpdateContent(context.Background(), uuid.New(), content.ID, usecases.CreateContentInput{
+		Title:           content.Title,
+		Description:     content.Description,
+		AccessLevel:     content.AccessLevel,
+		DurationSeconds: content.DurationSeconds,
+		Published:       true,
+	})
+
+	assert.NoError(t, err)
+	mockRevisionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaveDraft_LeavesLiveContentUntouched(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	content := revisionTestContent()
+
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockRevisionRepo.On("SaveDraft", mock.Anything, mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		return revision.Status == domain.RevisionStatusDraft && revision.Snapshot.Title == "Working Title"
+	})).Return(nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	draft, err := uc.SaveDraft(context.Background(), uuid.New(), content.ID, usecases.CreateContentInput{
+		Title:           "Working Title",
+		AccessLevel:     domain.AccessLevelFree,
+		DurationSeconds: 3600,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Original", content.Title)
+	assert.Contains(t, draft.Changes, "title")
+	assert.Contains(t, draft.Changes, "published")
+	mockContentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestRestoreRevision_PublishesDraftAndDiscardsIt(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	content := revisionTestContent()
+	snapshot := content.Snapshot()
+	snapshot.Title = "From Draft"
+
+	mockRevisionRepo.On("GetByNumber", mock.Anything, content.ID, 4).Return(&domain.ContentRevision{
+		ContentID: content.ID, Number: 4, Status: domain.RevisionStatusDraft, Snapshot: snapshot,
+	}, nil)
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockRevisionRepo.On("Record", mock.Anything, content, mock.MatchedBy(func(revision *domain.ContentRevision) bool {
+		return revision.Status == domain.RevisionStatusPublished && *revision.RestoredFrom == 4
+	}), true).Return(nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	result, err := uc.RestoreRevision(context.Background(), uuid.New(), content.ID, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "From Draft", result.Title)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestRestoreRevision_NotFound(t *testing.T) {
+	mockRevisionRepo := new(MockContentRevisionRepository)
+	contentID := uuid.New()
+	mockRevisionRepo.On("GetByNumber", mock.Anything, contentID, 9).Return(nil, domain.ErrRevisionNotFound)
+
+	uc := usecases.NewContentUseCase(new(MockContentRepository), new(MockSubscriptionRepository), new(MockUserRepository), mockRevisionRepo)
+	result, err := uc.RestoreRevision(context.Background(), uuid.New(), contentID, 9)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrRevisionNotFound, err)
+}
@@ -0,0 +1,142 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func utcDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestComputeStreaks(t *testing.T) {
+	days := []time.Time{
+		utcDay(2026, 3, 1), utcDay(2026, 3, 2), utcDay(2026, 3, 3),
+		utcDay(2026, 3, 10),
+		utcDay(2026, 3, 14), utcDay(2026, 3, 15),
+	}
+
+	streaks := domain.ComputeStreaks(days, utcDay(2026, 3, 16).Add(20*time.Hour))
+	assert.Equal(t, 3, streaks.LongestDays)
+	assert.Equal(t, 2, streaks.CurrentDays)
+	assert.Equal(t, 6, streaks.ActiveDays)
+
+	assert.Equal(t, 0, domain.ComputeStreaks(days, utcDay(2026, 3, 17)).CurrentDays)
+	assert.Equal(t, domain.Streaks{}, domain.ComputeStreaks(nil, utcDay(2026, 3, 17)))
+}
+
+func TestStatsPeriod_Since(t *testing.T) {
+	now := utcDay(2026, 10, 19)
+
+	from, ok := domain.StatsPeriodWeek.Since(now)
+	assert.True(t, ok)
+	assert.Equal(t, utcDay(2026, 10, 12), from)
+
+	from, ok = domain.StatsPeriodAll.Since(now)
+	assert.True(t, ok)
+	assert.True(t, from.IsZero())
+
+	_, ok = domain.StatsPeriod("decade").Since(now)
+	assert.False(t, ok)
+}
+
+func TestGetStats_AggregatesAndCaches(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockCache := new(MockCache)
+	userID := uuid.New()
+	today := time.Now().UTC()
+	genres := []*domain.GenreActivity{{Genre: "drama", Titles: 4, WatchSeconds: 20000}}
+	days := []time.Time{utcDay(today.Year(), today.Month(), today.Day()).AddDate(0, 0, -1), utcDay(today.Year(), today.Month(), today.Day())}
+
+	cacheKey := fmt.Sprintf("user_stats:%s:week", userID)
+	mockCache.On("Get", mock.Anything, cacheKey).Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, cacheKey, mock.Anything, 10*time.Minute).Return(nil)
+	mockWatchRepo.On("GetWatchTotals", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.WatchTotals{WatchSeconds: 36000, TitlesWatched: 6, TitlesCompleted: 3}, nil)
+	mockWatchRepo.On("GetTopGenres", mock.Anything, userID, mock.Anything, mock.Anything, 3).Return(genres, nil)
+	mockWatchRepo.On("GetActivityDays", mock.Anything, userID, mock.Anything, mock.Anything).Return(days, nil)
+
+	statsUseCase := usecases.NewStatsUseCase(mockWatchRepo, mockCache)
+	stats, err := statsUseCase.GetStats(context.Background(), userID, domain.StatsPeriodWeek)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatsPeriodWeek, stats.Period)
+	assert.NotNil(t, stats.From)
+	assert.Equal(t, int64(36000), stats.WatchSeconds)
+	assert.Equal(t, int64(3), stats.TitlesCompleted)
+	assert.Equal(t, "drama", stats.FavoriteGenres[0].Genre)
+	assert.Equal(t, 2, stats.Streaks.CurrentDays)
+	mockWatchRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestGetStats_ServedFromCache(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockCache := new(MockCache)
+	userID := uuid.New()
+	cached, _ := json.Marshal(domain.ViewerStats{Period: domain.StatsPeriodAll, WatchTotals: domain.WatchTotals{TitlesWatched: 12}})
+
+	mockCache.On("Get", mock.Anything, fmt.Sprintf("user_stats:%s:all", userID)).Return(string(cached), nil)
+
+	statsUseCase := usecases.NewStatsUseCase(mockWatchRepo, mockCache)
+	stats, err := statsUseCase.GetStats(context.Background(), userID, domain.StatsPeriodAll)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), stats.TitlesWatched)
+	mockWatchRepo.AssertNotCalled(t, "GetWatchTotals", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetStats_InvalidPeriod(t *testing.T) {
+	statsUseCase := usecases.NewStatsUseCase(new(MockWatchHistoryRepository), new(MockCache))
+	_, err := statsUseCase.GetStats(context.Background(), uuid.New(), domain.StatsPeriod("decade"))
+	assert.Equal(t, domain.ErrInvalidStatsPeriod, err)
+}
+
+func TestGetYearInReview_CoversCalendarYear(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockCache := new(MockCache)
+	userID := uuid.New()
+	year := time.Now().UTC().Year() - 1
+	from, to := utcDay(year, 1, 1), utcDay(year+1, 1, 1)
+	titles := []*domain.TitleActivity{{ContentID: uuid.New(), WatchSeconds: 14400, RewatchCount: 1, Completed: true}}
+	months := []*domain.MonthActivity{{Month: 3, Titles: 2, WatchSeconds: 9000}, {Month: 12, Titles: 1, WatchSeconds: 5400}}
+
+	mockCache.On("Get", mock.Anything, mock.Anything).Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, fmt.Sprintf("year_in_review:%s:%d", userID, year), mock.Anything, 24*time.Hour).Return(nil)
+	mockWatchRepo.On("GetWatchTotals", mock.Anything, userID, from, to).Return(&domain.WatchTotals{WatchSeconds: 14400, TitlesWatched: 3}, nil)
+	mockWatchRepo.On("GetTopGenres", mock.Anything, userID, from, to, 3).Return([]*domain.GenreActivity{}, nil)
+	mockWatchRepo.On("GetTopTitles", mock.Anything, userID, from, to, 5).Return(titles, nil)
+	mockWatchRepo.On("GetMonthlyActivity", mock.Anything, userID, from, to).Return(months, nil)
+	mockWatchRepo.On("GetActivityDays", mock.Anything, userID, from, to).Return([]time.Time{utcDay(year, 3, 4)}, nil)
+
+	statsUseCase := usecases.NewStatsUseCase(mockWatchRepo, mockCache)
+	review, err := statsUseCase.GetYearInReview(context.Background(), userID, year)
+
+	assert.NoError(t, err)
+	assert.Equal(t, year, review.Year)
+	assert.Len(t, review.Months, 12)
+	assert.Equal(t, int64(9000), review.Months[2].WatchSeconds)
+	assert.Equal(t, int64(0), review.Months[5].WatchSeconds)
+	assert.Equal(t, 12, review.Months[11].Month)
+	assert.Equal(t, titles, review.TopTitles)
+	assert.Equal(t, 1, review.Streaks.LongestDays)
+	assert.Equal(t, 0, review.Streaks.CurrentDays)
+	mockWatchRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestGetYearInReview_RejectsFutureYear(t *testing.T) {
+	statsUseCase := usecases.NewStatsUseCase(new(MockWatchHistoryRepository), new(MockCache))
+	_, err := statsUseCase.GetYearInReview(context.Background(), uuid.New(), time.Now().UTC().Year()+1)
+	assert.Equal(t, domain.ErrInvalidReviewYear, err)
+}
@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/infrastructure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutAndDelete(t *testing.T) {
+	root := t.TempDir()
+	storage, err := infrastructure.NewLocalStorage(root, "/media/")
+	require.NoError(t, err)
+
+	err = storage.Put(context.Background(), "artwork/ab/abc.png", strings.NewReader("png-bytes"), 9, "image/png")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(root, "artwork", "ab", "abc.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png-bytes", string(data))
+	assert.Equal(t, "/media/artwork/ab/abc.png", storage.URL("artwork/ab/abc.png"))
+
+	require.NoError(t, storage.Delete(context.Background(), "artwork/ab/abc.png"))
+	_, err = os.Stat(filepath.Join(root, "artwork", "ab", "abc.png"))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, storage.Delete(context.Background(), "artwork/ab/abc.png"))
+}
+
+func TestLocalStorage_RejectsEscapingKeys(t *testing.T) {
+	storage, err := infrastructure.NewLocalStorage(t.TempDir(), "/media")
+	require.NoError(t, err)
+
+	for _, key := range []string{"../etc/passwd", "/abs/key", "a/../../b", "", "a//b"} {
+		err := storage.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain")
+		assert.Equal(t, infrastructure.ErrInvalidObjectKey, err, key)
+	}
+}
+
+func TestS3Storage_PutSignsRequest(t *testing.T) {
+	var gotMethod, gotPath, gotBody, gotAuth, gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		assert.Equal(t, "UNSIGNED-PAYLOAD", r.Header.Get("x-amz-content-sha256"))
+		assert.NotEmpty(t, r.Header.Get("x-amz-date"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	storage, err := infrastructure.NewS3Storage(server.URL, "eu-west-1", "media", "AKIDEXAMPLE", "secret", "https://cdn.example.com")
+	require.NoError(t, err)
+
+	err = storage.Put(context.Background(), "avatars/cd/cdef.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, gotMethod)
+	assert.Equal(t, "/media/avatars/cd/cdef.jpg", gotPath)
+	assert.Equal(t, "jpeg", gotBody)
+	assert.Equal(t, "image/jpeg", gotContentType)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+	assert.Contains(t, gotAuth, "/eu-west-1/s3/aws4_request")
+	assert.Contains(t, gotAuth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date")
+	assert.Equal(t, "https://cdn.example.com/avatars/cd/cdef.jpg", storage.URL("avatars/cd/cdef.jpg"))
+}
+
+func TestS3Storage_SurfacesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	defer server.Close()
+
+	storage, err := infrastructure.NewS3Storage(server.URL, "", "media", "AKIDEXAMPLE", "secret", "")
+	require.NoError(t, err)
+
+	err = storage.Delete(context.Background(), "artwork/ab/abc.png")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+	assert.Equal(t, server.URL+"/media/artwork/ab/abc.png", storage.URL("artwork/ab/abc.png"))
+}
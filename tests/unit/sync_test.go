@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func syncEvent(contentID uuid.UUID, watched int, at time.Time) usecases.SyncEvent {
+	return usecases.SyncEvent{ContentID: contentID, WatchedSeconds: &watched, ClientTime: at}
+}
+
+func TestSyncWatchHistory_LastWriterWinsByClientTime(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}
+	storedAt := time.Now().Add(-time.Hour)
+	existing := &domain.WatchHistory{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ContentID:      content.ID,
+		WatchedSeconds: 3000,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusPaused,
+		LastWatchedAt:  storedAt,
+		DeviceID:       "tv",
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(existing, nil)
+	mockWatchRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	result, err := watchUseCase.SyncWatchHistory(context.Background(), userID, "", usecases.SyncWatchHistoryInput{
+		DeviceID: "phone",
+		Events: []usecases.SyncEvent{
+			syncEvent(content.ID, 4200, storedAt.Add(20*time.Minute)),
+			syncEvent(content.ID, 1200, storedAt.Add(-30*time.Minute)),
+			syncEvent(content.ID, 3600, storedAt.Add(10*time.Minute)),
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, usecases.SyncEventApplied, result.Results[0].Status)
+	assert.Equal(t, usecases.SyncEventStale, result.Results[1].Status)
+	assert.Equal(t, usecases.SyncEventApplied, result.Results[2].Status)
+	assert.Len(t, result.Histories, 1)
+	assert.Equal(t, 4200, result.Histories[0].WatchedSeconds)
+	assert.Equal(t, "phone", result.Histories[0].DeviceID)
+	assert.True(t, result.Histories[0].LastWatchedAt.Equal(storedAt.Add(20*time.Minute)))
+	mockWatchRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestSyncWatchHistory_StaleUploadKeepsStoredProgress(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}
+	existing := &domain.WatchHistory{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ContentID:      content.ID,
+		WatchedSeconds: 5000,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusPaused,
+		LastWatchedAt:  time.Now(),
+	}
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, content.ID).Return(existing, nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	result, err := watchUseCase.SyncWatchHistory(context.Background(), userID, "", usecases.SyncWatchHistoryInput{
+		DeviceID: "phone",
+		Events:   []usecases.SyncEvent{syncEvent(content.ID, 900, time.Now().Add(-24*time.Hour))},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, usecases.SyncEventStale, result.Results[0].Status)
+	assert.Equal(t, 5000, result.Histories[0].WatchedSeconds)
+	mockWatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSyncWatchHistory_RejectsInvalidEvents(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	free := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelFree, DurationSeconds: 1800}
+	premium := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelPremium, DurationSeconds: 3600}
+	mockContentRepo.On("GetByID", mock.Anything, free.ID).Return(free, nil)
+	mockContentRepo.On("GetByID", mock.Anything, premium.ID).Return(premium, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, free.ID).Return(nil, domain.ErrWatchHistoryNotFound)
+	mockWatchRepo.On("Create", mock.Anything, mock.MatchedBy(func(history *domain.WatchHistory) bool {
+		return history.ContentID == free.ID && history.WatchedSeconds == 600 && history.DeviceID == "tablet"
+	})).Return(nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+	now := time.Now()
+	result, err := watchUseCase.SyncWatchHistory(context.Background(), userID, "", usecases.SyncWatchHistoryInput{
+		DeviceID: "tablet",
+		Events: []usecases.SyncEvent{
+			syncEvent(free.ID, 600, now.Add(-time.Hour)),
+			syncEvent(free.ID, 1900, now.Add(-30*time.Minute)),
+			syncEvent(free.ID, 700, now.Add(time.Hour)),
+			syncEvent(premium.ID, 60, now.Add(-time.Hour)),
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, usecases.SyncEventApplied, result.Results[0].Status)
+	assert.Equal(t, usecases.SyncEventResult{Index: 1, ContentID: free.ID, Status: usecases.SyncEventRejected, Error: domain.ErrProgressBeyondDuration.Error()}, result.Results[1])
+	assert.Equal(t, domain.ErrClientTimeInFuture.Error(), result.Results[2].Error)
+	assert.Equal(t, domain.ErrContentNotAccessible.Error(), result.Results[3].Error)
+	assert.Len(t, result.Histories, 1)
+	mockWatchRepo.AssertExpectations(t)
+}
@@ -0,0 +1,243 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/signedurl"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/pkg/subtitles"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTrackRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrackRepository) CreateSubtitle(ctx context.Context, track *domain.SubtitleTrack) error {
+	args := m.Called(ctx, track)
+	return args.Error(0)
+}
+
+func (m *MockTrackRepository) GetSubtitle(ctx context.Context, contentID, id uuid.UUID) (*domain.SubtitleTrack, error) {
+	args := m.Called(ctx, contentID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockTrackRepository) ListSubtitles(ctx context.Context, contentID uuid.UUID) ([]*domain.SubtitleTrack, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockTrackRepository) DeleteSubtitle(ctx context.Context, contentID, id uuid.UUID) error {
+	args := m.Called(ctx, contentID, id)
+	return args.Error(0)
+}
+
+func (m *MockTrackRepository) CreateAudioTrack(ctx context.Context, track *domain.AudioTrack) error {
+	args := m.Called(ctx, track)
+	return args.Error(0)
+}
+
+func (m *MockTrackRepository) ListAudioTracks(ctx context.Context, contentID uuid.UUID) ([]*domain.AudioTrack, error) {
+	args := m.Called(ctx, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AudioTrack), args.Error(1)
+}
+
+func (m *MockTrackRepository) DeleteAudioTrack(ctx context.Context, contentID, id uuid.UUID) error {
+	args := m.Called(ctx, contentID, id)
+	return args.Error(0)
+}
+
+const sampleSRT = "\xef\xbb\xbf1\r\n00:00:01,000 --> 00:00:04,250\r\n<font color=\"#ffff00\">Hello</font> there\r\n\r\n2\r\n00:01:02,500 --> 00:01:05,000 X1:10 X2:20 Y1:30 Y2:40\r\n<i>Second</i> line\r\nwraps here\r\n"
+
+func TestSRTToWebVTT(t *testing.T) {
+	vtt, err := subtitles.SRTToWebVTT([]byte(sampleSRT))
+
+	require.NoError(t, err)
+	expected := "WEBVTT\n\n1\n00:00:01.000 --> 00:00:04.250\nHello there\n\n2\n00:01:02.500 --> 00:01:05.000\n<i>Second</i> line\nwraps here\n"
+	assert.Equal(t, expected, string(vtt))
+	assert.NoError(t, subtitles.ValidateWebVTT(vtt))
+}
+
+func TestSRTToWebVTT_Malformed(t *testing.T) {
+	_, err := subtitles.SRTToWebVTT([]byte("1\nnot a timing line\nHello\n"))
+	assert.ErrorIs(t, err, subtitles.ErrMalformed)
+
+	_, err = subtitles.SRTToWebVTT([]byte("   \n\n"))
+	assert.ErrorIs(t, err, subtitles.ErrMalformed)
+}
+
+func TestNormalizeLanguageTag(t *testing.T) {
+	tag, err := domain.NormalizeLanguageTag("pt_br")
+	assert.NoError(t, err)
+	assert.Equal(t, "pt-BR", tag)
+
+	tag, err = domain.NormalizeLanguageTag("ZH-hant-tw")
+	assert.NoError(t, err)
+	assert.Equal(t, "zh-Hant-TW", tag)
+
+	_, err = domain.NormalizeLanguageTag("english!")
+	assert.Equal(t, domain.ErrInvalidLanguageTag, err)
+}
+
+func TestAddSubtitle_ConvertsSRTUpload(t *testing.T) {
+	mockTrackRepo := new(MockTrackRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockTrackRepo.On("ListSubtitles", mock.Anything, contentID).Return([]*domain.SubtitleTrack{}, nil)
+	mockTrackRepo.On("CreateSubtitle", mock.Anything, mock.AnythingOfType("*domain.SubtitleTrack")).Return(nil)
+
+	trackUseCase := usecases.NewTrackUseCase(mockTrackRepo, mockContentRepo, signedurl.NewSigner([]byte("test-key")))
+	track, err := trackUseCase.AddSubtitle(context.Background(), contentID, usecases.AddSubtitleInput{
+		Language: "es",
+		Label:    "Español",
+		File:     []byte(sampleSRT),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubtitleFormatWebVTT, track.Format)
+	assert.True(t, strings.HasPrefix(track.Body, "WEBVTT\n"))
+	assert.Equal(t, "/api/v1/content/"+contentID.String()+"/subtitles/"+track.ID.String()+".vtt", track.URL)
+}
+
+func TestAddSubtitle_RegisterSRTURL(t *testing.T) {
+	mockTrackRepo := new(MockTrackRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockTrackRepo.On("ListSubtitles", mock.Anything, contentID).Return([]*domain.SubtitleTrack{}, nil)
+	mockTrackRepo.On("CreateSubtitle", mock.Anything, mock.AnythingOfType("*domain.SubtitleTrack")).Return(nil)
+
+	trackUseCase := usecases.NewTrackUseCase(mockTrackRepo, mockContentRepo, signedurl.NewSigner([]byte("test-key")))
+	track, err := trackUseCase.AddSubtitle(context.Background(), contentID, usecases.AddSubtitleInput{
+		Language: "fr",
+		URL:      "https://cdn.example.com/subs/fr.SRT?v=2",
+		IsSDH:    true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubtitleFormatSRT, track.Format)
+	assert.Empty(t, track.Body)
+}
+
+func TestAddSubtitle_Validation(t *testing.T) {
+	mockTrackRepo := new(MockTrackRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockTrackRepo.On("ListSubtitles", mock.Anything, contentID).Return([]*domain.SubtitleTrack{
+		{ContentID: contentID, Language: "en"},
+	}, nil)
+
+	trackUseCase := usecases.NewTrackUseCase(mockTrackRepo, mockContentRepo, signedurl.NewSigner([]byte("test-key")))
+
+	_, err := trackUseCase.AddSubtitle(context.Background(), contentID, usecases.AddSubtitleInput{Language: "en"})
+	assert.Equal(t, domain.ErrSubtitleSource, err)
+
+	_, err = trackUseCase.AddSubtitle(context.Background(), contentID, usecases.AddSubtitleInput{Language: "EN", URL: "https://cdn.example.com/en.vtt"})
+	assert.Equal(t, domain.ErrTrackExists, err)
+
+	_, err = trackUseCase.AddSubtitle(context.Background(), contentID, usecases.AddSubtitleInput{Language: "en", IsForced: true, Format: domain.SubtitleFormatWebVTT, File: []byte("not vtt")})
+	assert.Equal(t, domain.ErrInvalidSubtitle, err)
+	mockTrackRepo.AssertNotCalled(t, "CreateSubtitle", mock.Anything, mock.Anything)
+}
+
+func TestAddAudioTrack_SingleDefault(t *testing.T) {
+	mockTrackRepo := new(MockTrackRepository)
+	mockContentRepo := new(MockContentRepository)
+
+	contentID := uuid.New()
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID}, nil)
+	mockTrackRepo.On("ListAudioTracks", mock.Anything, contentID).Return([]*domain.AudioTrack{
+		{ContentID: contentID, Language: "en", Codec: "mp4a.40.2", IsDefault: true},
+	}, nil)
+
+	trackUseCase := usecases.NewTrackUseCase(mockTrackRepo, mockContentRepo, signedurl.NewSigner([]byte("test-key")))
+	track, err := trackUseCase.AddAudioTrack(context.Background(), contentID, usecases.AddAudioTrackInput{
+		Language:  "de",
+		Codec:     "mp4a.40.2",
+		IsDefault: true,
+		URL:       "https://cdn.example.com/audio/de.m3u8",
+	})
+
+	assert.Nil(t, track)
+	assert.Equal(t, domain.ErrDefaultTrackExists, err)
+}
+
+func TestGetSubtitleFile_RequiresSignedURL(t *testing.T) {
+	mockTrackRepo := new(MockTrackRepository)
+	mockContentRepo := new(MockContentRepository)
+	signer := signedurl.NewSigner([]byte("test-key"))
+
+	contentID := uuid.New()
+	trackID := uuid.New()
+	path := "/api/v1/content/" + contentID.String() + "/subtitles/" + trackID.String() + ".vtt"
+	mockTrackRepo.On("GetSubtitle", mock.Anything, contentID, trackID).Return(&domain.SubtitleTrack{ID: trackID, ContentID: contentID, Body: "WEBVTT\n"}, nil)
+
+	trackUseCase := usecases.NewTrackUseCase(mockTrackRepo, mockContentRepo, signer)
+
+	_, err := trackUseCase.GetSubtitleFile(context.Background(), contentID, trackID, path, "203.0.113.7")
+	assert.Equal(t, domain.ErrForbidden, err)
+
+	signed, err := signer.Sign(path, signedurl.Claims{UserID: uuid.NewString(), ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	body, err := trackUseCase.GetSubtitleFile(context.Background(), contentID, trackID, signed, "203.0.113.7")
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n", string(body))
+}
+
+func TestCreatePlaybackSession_IncludesSignedTracks(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockRenditionRepo := new(MockRenditionRepository)
+	mockTrackRepo := new(MockTrackRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	content := &domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, Published: true, VideoURL: testVideoURL}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
+	mockRenditionRepo.On("GetByContentID", mock.Anything, contentID).Return([]*domain.VideoRendition{}, nil)
+	mockTrackRepo.On("ListSubtitles", mock.Anything, contentID).Return([]*domain.SubtitleTrack{
+		{ContentID: contentID, Language: "es", Format: domain.SubtitleFormatWebVTT, URL: "/api/v1/content/" + contentID.String() + "/subtitles/x.vtt"},
+	}, nil)
+	mockTrackRepo.On("ListAudioTracks", mock.Anything, contentID).Return([]*domain.AudioTrack{
+		{ContentID: contentID, Language: "en", Codec: "mp4a.40.2", Channels: 6, IsDefault: true, URL: "https://cdn.example.com/audio/en.m3u8"},
+	}, nil)
+
+	contentUseCase := usecases.NewContentUseCase(mockContentRepo, mockSubRepo, new(MockUserRepository), new(MockContentRevisionRepository))
+	signer := signedurl.NewSigner([]byte("test-key"))
+	playbackUseCase := usecases.NewPlaybackUseCase(contentUseCase, mockRenditionRepo, mockTrackRepo, mockSubRepo, signer, time.Hour)
+	session, err := playbackUseCase.CreatePlaybackSession(context.Background(), contentID, userID, "", "", usecases.PlaybackInput{})
+
+	require.NoError(t, err)
+	require.Len(t, session.Subtitles, 1)
+	require.Len(t, session.AudioTracks, 1)
+	assert.Equal(t, "es", session.Subtitles[0].Language)
+	assert.Equal(t, 6, session.AudioTracks[0].Channels)
+	_, err = signer.Verify(session.Subtitles[0].URL, "", time.Now())
+	assert.NoError(t, err)
+	_, err = signer.Verify(session.AudioTracks[0].URL, "", time.Now())
+	assert.NoError(t, err)
+}
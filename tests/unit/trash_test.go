@@ -0,0 +1,66 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestTrashPurgeJob_PurgesPastRetention(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockPlanRepo := new(MockPlanRepository)
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-30 * 24 * time.Hour)
+
+	mockContentRepo.On("Purge", mock.Anything, cutoff).Return(int64(2), nil)
+	mockPlanRepo.On("Purge", mock.Anything, cutoff).Return(int64(1), nil)
+
+	job := usecases.NewTrashPurgeJob(mockContentRepo, mockPlanRepo, 30*24*time.Hour)
+	err := job.Run(context.Background(), now)
+
+	assert.NoError(t, err)
+	mockContentRepo.AssertExpectations(t)
+	mockPlanRepo.AssertExpectations(t)
+}
+
+func TestTrashPurgeJob_StopsOnContentError(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	mockPlanRepo := new(MockPlanRepository)
+	mockContentRepo.On("Purge", mock.Anything, mock.Anything).Return(int64(0), errors.New("db down"))
+
+	job := usecases.NewTrashPurgeJob(mockContentRepo, mockPlanRepo, time.Hour)
+	err := job.Run(context.Background(), time.Now())
+
+	assert.Error(t, err)
+	mockPlanRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+}
+
+func TestRestoreContent_Success(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	contentID := uuid.New()
+	mockContentRepo.On("Restore", mock.Anything, contentID).Return(nil)
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, Title: "Back"}, nil)
+
+	uc := usecases.NewContentUseCase(mockContentRepo, new(MockSubscriptionRepository), new(MockUserRepository), new(MockContentRevisionRepository))
+	content, err := uc.RestoreContent(context.Background(), contentID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Back", content.Title)
+	mockContentRepo.AssertExpectations(t)
+}
+
+func TestContent_DeletedIsNeverAvailable(t *testing.T) {
+	content := &domain.Content{Published: true}
+	assert.True(t, content.IsAvailableAt(time.Now()))
+
+	content.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	assert.False(t, content.IsAvailableAt(time.Now()))
+}
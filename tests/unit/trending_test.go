@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrendingRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrendingRepository) ListTrending(ctx context.Context, since time.Time, country string, limit int) ([]*domain.ContentActivity, error) {
+	args := m.Called(ctx, since, country, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContentActivity), args.Error(1)
+}
+
+func TestGetTrending_WeeklyRegionalChartIsCached(t *testing.T) {
+	mockRepo := new(MockTrendingRepository)
+	mockCache := new(MockCache)
+	contentID := uuid.New()
+	chart := []*domain.ContentActivity{{ContentID: contentID, Content: &domain.Content{ID: contentID}, UniqueViewers: 42}}
+
+	mockCache.On("Get", mock.Anything, "trending:generation").Return("", errors.New("redis: nil"))
+	mockCache.On("Get", mock.Anything, "trending:0:week:DE:10").Return("", errors.New("redis: nil"))
+	mockCache.On("Set", mock.Anything, "trending:0:week:DE:10", mock.Anything, 30*time.Minute).Return(nil)
+	mockRepo.On("ListTrending", mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+		age := time.Since(since)
+		return age >= 7*24*time.Hour && age < 7*24*time.Hour+time.Minute
+	}), "DE", 10).Return(chart, nil)
+
+	uc := usecases.NewTrendingUseCase(mockRepo, mockCache)
+	result, err := uc.GetTrending(context.Background(), domain.TrendingWindowWeek, "DE", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, chart, result)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestGetTrending_ServesFromCache(t *testing.T) {
+	mockRepo := new(MockTrendingRepository)
+	mockCache := new(MockCache)
+	mockCache.On("Get", mock.Anything, "trending:generation").Return("4", nil)
+	mockCache.On("Get", mock.Anything, "trending:4:day::5").Return(`[{"unique_viewers":3,"content":{"title":"Hit"}}]`, nil)
+
+	uc := usecases.NewTrendingUseCase(mockRepo, mockCache)
+	result, err := uc.GetTrending(context.Background(), domain.TrendingWindowDay, "", 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(3), result[0].UniqueViewers)
+	assert.Equal(t, "Hit", result[0].Content.Title)
+	mockRepo.AssertNotCalled(t, "ListTrending", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrending_ContentEventBumpsCacheGeneration(t *testing.T) {
+	mockCache := new(MockCache)
+	mockCache.On("Increment", mock.Anything, "trending:generation").Return(5, nil)
+
+	uc := usecases.NewTrendingUseCase(new(MockTrendingRepository), mockCache)
+	uc.HandleContentEvent(context.Background(), domain.ContentEvent{Type: domain.ContentEventExpired, ContentID: uuid.New()})
+
+	mockCache.AssertExpectations(t)
+}
+
+func TestGetTrending_InvalidWindow(t *testing.T) {
+	uc := usecases.NewTrendingUseCase(new(MockTrendingRepository), new(MockCache))
+	_, err := uc.GetTrending(context.Background(), domain.TrendingWindow("month"), "", 10)
+	assert.Equal(t, domain.ErrInvalidTrendingWindow, err)
+}
@@ -3,15 +3,20 @@ package unit
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/repositories"
 	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
+var defaultCompletion = domain.CompletionPolicies{Default: domain.DefaultCompletionPolicy}
+
 type MockWatchHistoryRepository struct {
 	mock.Mock
 }
@@ -37,8 +42,8 @@ func (m *MockWatchHistoryRepository) GetByUserAndContent(ctx context.Context, us
 	return args.Get(0).(*domain.WatchHistory), args.Error(1)
 }
 
-func (m *MockWatchHistoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.WatchHistory, int64, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockWatchHistoryRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page repositories.Page) ([]*domain.WatchHistory, int64, error) {
+	args := m.Called(ctx, userID, page)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -57,11 +62,64 @@ func (m *MockWatchHistoryRepository) GetContinueWatching(ctx context.Context, us
 	return args.Get(0).([]*domain.WatchHistory), args.Error(1)
 }
 
+func (m *MockWatchHistoryRepository) GetByUserAndContentIDs(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) ([]*domain.WatchHistory, error) {
+	args := m.Called(ctx, userID, contentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WatchHistory), args.Error(1)
+}
+
 func (m *MockWatchHistoryRepository) Update(ctx context.Context, history *domain.WatchHistory) error {
 	args := m.Called(ctx, history)
 	return args.Error(0)
 }
 
+func (m *MockWatchHistoryRepository) UpdateProgressBatch(ctx context.Context, histories []*domain.WatchHistory) error {
+	args := m.Called(ctx, histories)
+	return args.Error(0)
+}
+
+func (m *MockWatchHistoryRepository) GetWatchTotals(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.WatchTotals, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WatchTotals), args.Error(1)
+}
+
+func (m *MockWatchHistoryRepository) GetTopGenres(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.GenreActivity, error) {
+	args := m.Called(ctx, userID, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GenreActivity), args.Error(1)
+}
+
+func (m *MockWatchHistoryRepository) GetTopTitles(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.TitleActivity, error) {
+	args := m.Called(ctx, userID, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TitleActivity), args.Error(1)
+}
+
+func (m *MockWatchHistoryRepository) GetMonthlyActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.MonthActivity, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MonthActivity), args.Error(1)
+}
+
+func (m *MockWatchHistoryRepository) GetActivityDays(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]time.Time, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
 func (m *MockWatchHistoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -86,7 +144,7 @@ func TestCreateWatchHistory_FreeContent(t *testing.T) {
 	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(nil, domain.ErrWatchHistoryNotFound)
 	mockWatchRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.WatchHistory")).Return(nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
 
 	watchedSeconds := 1800
 	input := usecases.WatchHistoryInput{
@@ -94,7 +152,7 @@ func TestCreateWatchHistory_FreeContent(t *testing.T) {
 		WatchedSeconds: &watchedSeconds,
 	}
 
-	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, input)
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", input)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -122,7 +180,7 @@ func TestCreateWatchHistory_PremiumContent_NoSubscription(t *testing.T) {
 	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
 	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(nil, domain.ErrSubscriptionNotFound)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
 
 	watchedSeconds := 1800
 	input := usecases.WatchHistoryInput{
@@ -130,7 +188,7 @@ func TestCreateWatchHistory_PremiumContent_NoSubscription(t *testing.T) {
 		WatchedSeconds: &watchedSeconds,
 	}
 
-	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, input)
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", input)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -165,7 +223,7 @@ func TestUpdateWatchHistory_ExistingEntry(t *testing.T) {
 	mockWatchRepo.On("GetByUserAndContent", mock.Anything, userID, contentID).Return(existingHistory, nil)
 	mockWatchRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.WatchHistory")).Return(nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
 
 	watchedSeconds := 3600
 	input := usecases.WatchHistoryInput{
@@ -173,7 +231,7 @@ func TestUpdateWatchHistory_ExistingEntry(t *testing.T) {
 		WatchedSeconds: &watchedSeconds,
 	}
 
-	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, input)
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", input)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -182,6 +240,37 @@ func TestUpdateWatchHistory_ExistingEntry(t *testing.T) {
 	mockWatchRepo.AssertExpectations(t)
 }
 
+func TestCreateWatchHistory_ContentExpired(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	contentID := uuid.New()
+	licenceEnd := time.Now().Add(-time.Hour)
+
+	content := &domain.Content{
+		ID:              contentID,
+		AccessLevel:     domain.AccessLevelFree,
+		DurationSeconds: 7200,
+		AvailableUntil:  &licenceEnd,
+	}
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+
+	watchedSeconds := 60
+	result, err := watchUseCase.CreateOrUpdateWatchHistory(context.Background(), userID, "", usecases.WatchHistoryInput{
+		ContentID:      contentID,
+		WatchedSeconds: &watchedSeconds,
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailable, err)
+	mockWatchRepo.AssertNotCalled(t, "GetByUserAndContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGetWatchHistory_Success(t *testing.T) {
 	mockWatchRepo := new(MockWatchHistoryRepository)
 	mockContentRepo := new(MockContentRepository)
@@ -193,10 +282,14 @@ func TestGetWatchHistory_Success(t *testing.T) {
 		{ID: uuid.New(), UserID: userID, ContentID: uuid.New()},
 	}
 
-	mockWatchRepo.On("GetByUserID", mock.Anything, userID, 20, 0).Return(histories, int64(2), nil)
+	mockWatchRepo.On("GetByUserID", mock.Anything, userID, repositories.Page{
+		Sort: repositories.SortLastWatchedAt, Desc: true, Limit: 21,
+	}).Return(histories, int64(2), nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
-	result, total, err := watchUseCase.GetWatchHistory(context.Background(), userID, 20, 0)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+	result, total, _, err := watchUseCase.GetWatchHistory(context.Background(), userID, repositories.Page{
+		Sort: repositories.SortLastWatchedAt, Desc: true, Limit: 20,
+	})
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -204,6 +297,36 @@ func TestGetWatchHistory_Success(t *testing.T) {
 	mockWatchRepo.AssertExpectations(t)
 }
 
+func TestGetWatchHistory_MarksDeletedContentUnavailable(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+
+	userID := uuid.New()
+	live := &domain.Content{ID: uuid.New(), Title: "Live", VideoURL: "https://cdn/live.m3u8"}
+	trashed := &domain.Content{
+		ID:        uuid.New(),
+		Title:     "Gone",
+		VideoURL:  "https://cdn/gone.m3u8",
+		DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+	histories := []*domain.WatchHistory{
+		{ID: uuid.New(), UserID: userID, ContentID: live.ID, Content: live},
+		{ID: uuid.New(), UserID: userID, ContentID: trashed.ID, Content: trashed},
+	}
+	mockWatchRepo.On("GetByUserID", mock.Anything, userID, mock.Anything).Return(histories, int64(2), nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, new(MockContentRepository), new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	result, _, _, err := watchUseCase.GetWatchHistory(context.Background(), userID, repositories.Page{
+		Sort: repositories.SortLastWatchedAt, Desc: true, Limit: 20,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result[0].ContentUnavailable)
+	assert.Equal(t, live, result[0].Content)
+	assert.True(t, result[1].ContentUnavailable)
+	assert.Equal(t, "Gone", result[1].Content.Title)
+	assert.Empty(t, result[1].Content.VideoURL)
+}
+
 func TestGetContinueWatching_Success(t *testing.T) {
 	mockWatchRepo := new(MockWatchHistoryRepository)
 	mockContentRepo := new(MockContentRepository)
@@ -222,7 +345,7 @@ func TestGetContinueWatching_Success(t *testing.T) {
 
 	mockWatchRepo.On("GetContinueWatching", mock.Anything, userID, 10).Return(histories, nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
 	result, err := watchUseCase.GetContinueWatching(context.Background(), userID)
 
 	assert.NoError(t, err)
@@ -247,10 +370,13 @@ func TestUpdateProgress_Success(t *testing.T) {
 	}
 
 	mockWatchRepo.On("GetByID", mock.Anything, historyID).Return(history, nil)
+	mockContentRepo.On("GetByID", mock.Anything, history.ContentID).Return(&domain.Content{AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}, nil)
 	mockWatchRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.WatchHistory")).Return(nil)
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	mockWatchlistRepo.On("Remove", mock.Anything, userID, history.ContentID).Return(nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
-	result, err := watchUseCase.UpdateProgress(context.Background(), userID, historyID, 6500)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, mockWatchlistRepo, defaultCompletion, nil)
+	result, err := watchUseCase.UpdateProgress(context.Background(), userID, historyID, "", 6500)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -275,8 +401,8 @@ func TestUpdateProgress_NotOwner(t *testing.T) {
 
 	mockWatchRepo.On("GetByID", mock.Anything, historyID).Return(history, nil)
 
-	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo)
-	result, err := watchUseCase.UpdateProgress(context.Background(), userID, historyID, 3600)
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+	result, err := watchUseCase.UpdateProgress(context.Background(), userID, historyID, "", 3600)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -284,6 +410,50 @@ func TestUpdateProgress_NotOwner(t *testing.T) {
 	mockWatchRepo.AssertExpectations(t)
 }
 
+func TestUpdateProgress_DeletedContent(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+
+	userID := uuid.New()
+	historyID := uuid.New()
+	history := &domain.WatchHistory{
+		ID:           historyID,
+		UserID:       userID,
+		TotalSeconds: 7200,
+		Content:      &domain.Content{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+	}
+	mockWatchRepo.On("GetByID", mock.Anything, historyID).Return(history, nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, new(MockContentRepository), new(MockSubscriptionRepository), new(MockWatchlistRepository), defaultCompletion, nil)
+	result, err := watchUseCase.UpdateProgress(context.Background(), userID, historyID, "", 3600)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrContentNotAvailable, err)
+	mockWatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProgress_RechecksAccess(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockContentRepo := new(MockContentRepository)
+	mockSubRepo := new(MockSubscriptionRepository)
+
+	userID := uuid.New()
+	content := &domain.Content{ID: uuid.New(), AccessLevel: domain.AccessLevelPremium, DurationSeconds: 7200, BlockedCountries: domain.CountryCodes{"FR"}}
+	history := &domain.WatchHistory{ID: uuid.New(), UserID: userID, ContentID: content.ID, Content: content, TotalSeconds: 7200, WatchedSeconds: 600}
+	mockWatchRepo.On("GetByID", mock.Anything, history.ID).Return(history, nil)
+	mockContentRepo.On("GetByID", mock.Anything, content.ID).Return(content, nil)
+	mockSubRepo.On("GetActiveByUserID", mock.Anything, userID).Return(&domain.Subscription{EndDate: time.Now().Add(-time.Hour)}, nil)
+
+	watchUseCase := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, mockSubRepo, new(MockWatchlistRepository), defaultCompletion, nil)
+
+	_, err := watchUseCase.UpdateProgress(context.Background(), userID, history.ID, "FR", 900)
+	assert.Equal(t, domain.ErrContentNotAvailableInRegion, err)
+
+	_, err = watchUseCase.UpdateProgress(context.Background(), userID, history.ID, "US", 900)
+	assert.Equal(t, domain.ErrContentNotAccessible, err)
+	assert.Equal(t, 600, history.WatchedSeconds)
+	mockWatchRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
 func TestProgressPercentage_Calculation(t *testing.T) {
 	history := &domain.WatchHistory{
 		WatchedSeconds: 3600,
@@ -294,24 +464,35 @@ func TestProgressPercentage_Calculation(t *testing.T) {
 	assert.Equal(t, 50.0, percentage)
 }
 
-func TestIsCompleted_AtThreshold(t *testing.T) {
+func TestReachedCompletionPoint_AtThreshold(t *testing.T) {
 	history := &domain.WatchHistory{
 		WatchedSeconds: 6480,
 		TotalSeconds:   7200,
 		Status:         domain.WatchStatusPaused,
 	}
 
-	completed := history.IsCompleted()
+	completed := history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, nil)
 	assert.True(t, completed)
 }
 
-func TestIsCompleted_NotReached(t *testing.T) {
+func TestReachedCompletionPoint_NotReached(t *testing.T) {
 	history := &domain.WatchHistory{
 		WatchedSeconds: 3600,
 		TotalSeconds:   7200,
 		Status:         domain.WatchStatusPaused,
 	}
 
-	completed := history.IsCompleted()
+	completed := history.ReachedCompletionPoint(domain.DefaultCompletionPolicy, nil)
 	assert.False(t, completed)
 }
+
+func TestReachedCompletionPoint_UsesGivenPolicy(t *testing.T) {
+	history := &domain.WatchHistory{
+		WatchedSeconds: 3600,
+		TotalSeconds:   7200,
+		Status:         domain.WatchStatusPaused,
+	}
+
+	assert.True(t, history.ReachedCompletionPoint(domain.CompletionPolicy{Percentage: 50}, nil))
+	assert.False(t, history.ReachedCompletionPoint(domain.CompletionPolicy{Percentage: 75}, nil))
+}
@@ -0,0 +1,168 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/domain"
+	"github.com/etsrohan/Rohan-Srivastava_Golang-Backend-Practical-Task/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) Add(ctx context.Context, item *domain.WatchlistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) Exists(ctx context.Context, userID, contentID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, contentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistItem, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WatchlistItem), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Reorder(ctx context.Context, userID uuid.UUID, contentIDs []uuid.UUID) error {
+	args := m.Called(ctx, userID, contentIDs)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) Remove(ctx context.Context, userID, contentID uuid.UUID) error {
+	args := m.Called(ctx, userID, contentID)
+	return args.Error(0)
+}
+
+func TestAddToWatchlist_Success(t *testing.T) {
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	mockContentRepo := new(MockContentRepository)
+	userID, contentID := uuid.New(), uuid.New()
+
+	content := &domain.Content{ID: contentID, Published: true}
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(content, nil)
+	mockWatchlistRepo.On("Exists", mock.Anything, userID, contentID).Return(false, nil)
+	mockWatchlistRepo.On("Add", mock.Anything, mock.AnythingOfType("*domain.WatchlistItem")).Return(nil)
+
+	uc := usecases.NewWatchlistUseCase(mockWatchlistRepo, mockContentRepo, new(MockWatchHistoryRepository))
+	item, err := uc.AddToWatchlist(context.Background(), userID, "US", usecases.AddToWatchlistInput{ContentID: contentID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, item.UserID)
+	assert.Equal(t, content, item.Content)
+	mockWatchlistRepo.AssertExpectations(t)
+}
+
+func TestAddToWatchlist_Duplicate(t *testing.T) {
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	mockContentRepo := new(MockContentRepository)
+	userID, contentID := uuid.New(), uuid.New()
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, Published: true}, nil)
+	mockWatchlistRepo.On("Exists", mock.Anything, userID, contentID).Return(true, nil)
+
+	uc := usecases.NewWatchlistUseCase(mockWatchlistRepo, mockContentRepo, new(MockWatchHistoryRepository))
+	_, err := uc.AddToWatchlist(context.Background(), userID, "US", usecases.AddToWatchlistInput{ContentID: contentID})
+
+	assert.Equal(t, domain.ErrWatchlistItemExists, err)
+	mockWatchlistRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestAddToWatchlist_BlockedRegion(t *testing.T) {
+	mockContentRepo := new(MockContentRepository)
+	contentID := uuid.New()
+
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{
+		ID: contentID, Published: true, BlockedCountries: domain.CountryCodes{"DE"},
+	}, nil)
+
+	uc := usecases.NewWatchlistUseCase(new(MockWatchlistRepository), mockContentRepo, new(MockWatchHistoryRepository))
+	_, err := uc.AddToWatchlist(context.Background(), uuid.New(), "DE", usecases.AddToWatchlistInput{ContentID: contentID})
+
+	assert.Equal(t, domain.ErrContentNotAvailableInRegion, err)
+}
+
+func TestReorderWatchlist_RequiresEveryItem(t *testing.T) {
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	userID, a, b := uuid.New(), uuid.New(), uuid.New()
+	items := []*domain.WatchlistItem{{ContentID: a, Position: 1}, {ContentID: b, Position: 2}}
+	mockWatchlistRepo.On("ListByUserID", mock.Anything, userID).Return(items, nil)
+
+	uc := usecases.NewWatchlistUseCase(mockWatchlistRepo, new(MockContentRepository), new(MockWatchHistoryRepository))
+
+	err := uc.ReorderWatchlist(context.Background(), userID, usecases.ReorderWatchlistInput{ContentIDs: []uuid.UUID{b}})
+	assert.Equal(t, domain.ErrInvalidWatchlistOrder, err)
+	err = uc.ReorderWatchlist(context.Background(), userID, usecases.ReorderWatchlistInput{ContentIDs: []uuid.UUID{b, b}})
+	assert.Equal(t, domain.ErrInvalidWatchlistOrder, err)
+
+	mockWatchlistRepo.On("Reorder", mock.Anything, userID, []uuid.UUID{b, a}).Return(nil)
+	err = uc.ReorderWatchlist(context.Background(), userID, usecases.ReorderWatchlistInput{ContentIDs: []uuid.UUID{b, a}})
+	assert.NoError(t, err)
+	mockWatchlistRepo.AssertExpectations(t)
+}
+
+func TestGetWatchlist_MergesProgressAndHidesUnavailable(t *testing.T) {
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	userID := uuid.New()
+	expired := time.Now().Add(-time.Hour)
+
+	started := &domain.Content{ID: uuid.New(), Published: true}
+	unseen := &domain.Content{ID: uuid.New(), Published: true}
+	gone := &domain.Content{ID: uuid.New(), Published: true, AvailableUntil: &expired}
+	items := []*domain.WatchlistItem{
+		{ContentID: started.ID, Content: started, Position: 1},
+		{ContentID: gone.ID, Content: gone, Position: 2},
+		{ContentID: unseen.ID, Content: unseen, Position: 3},
+	}
+	mockWatchlistRepo.On("ListByUserID", mock.Anything, userID).Return(items, nil)
+	mockWatchRepo.On("GetByUserAndContentIDs", mock.Anything, userID, []uuid.UUID{started.ID, unseen.ID}).Return([]*domain.WatchHistory{
+		{ContentID: started.ID, WatchedSeconds: 600, TotalSeconds: 1200, Status: domain.WatchStatusPaused},
+	}, nil)
+
+	uc := usecases.NewWatchlistUseCase(mockWatchlistRepo, new(MockContentRepository), mockWatchRepo)
+	entries, err := uc.GetWatchlist(context.Background(), userID, "US")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, started.ID, entries[0].Content.ID)
+	assert.Equal(t, 50.0, entries[0].Progress.ProgressPercentage)
+	assert.Equal(t, unseen.ID, entries[1].Content.ID)
+	assert.Nil(t, entries[1].Progress)
+}
+
+func TestUpdateProgress_CompletionRemovesFromWatchlist(t *testing.T) {
+	mockWatchRepo := new(MockWatchHistoryRepository)
+	mockWatchlistRepo := new(MockWatchlistRepository)
+	userID, contentID, historyID := uuid.New(), uuid.New(), uuid.New()
+
+	history := &domain.WatchHistory{
+		ID: historyID, UserID: userID, ContentID: contentID,
+		WatchedSeconds: 3600, TotalSeconds: 7200, Status: domain.WatchStatusPaused,
+	}
+	mockContentRepo := new(MockContentRepository)
+	mockContentRepo.On("GetByID", mock.Anything, contentID).Return(&domain.Content{ID: contentID, AccessLevel: domain.AccessLevelFree, DurationSeconds: 7200}, nil)
+	mockWatchRepo.On("GetByID", mock.Anything, historyID).Return(history, nil)
+	mockWatchRepo.On("Update", mock.Anything, history).Return(nil)
+	mockWatchlistRepo.On("Remove", mock.Anything, userID, contentID).Return(domain.ErrWatchlistItemNotFound).Once()
+
+	uc := usecases.NewWatchHistoryUseCase(mockWatchRepo, mockContentRepo, new(MockSubscriptionRepository), mockWatchlistRepo, defaultCompletion, nil)
+	result, err := uc.UpdateProgress(context.Background(), userID, historyID, "", 7000)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WatchStatusCompleted, result.Status)
+
+	// Rewinding within the credits of a finished title does not touch the list again
+	_, err = uc.UpdateProgress(context.Background(), userID, historyID, "", 7100)
+	assert.NoError(t, err)
+	mockWatchlistRepo.AssertExpectations(t)
+}